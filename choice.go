@@ -0,0 +1,190 @@
+package survey
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2/core"
+)
+
+/*
+Choice is an option of a Select or MultiSelect that says more than its label. Value is
+what the answer carries, and defaults to the Label. The Description of the focused
+choice is shown under the list, and disabled choices are shown with their
+DisabledReason but can't be picked. Choices that share a Group are listed under a
+header with its name, so they should be next to each other.
+
+	region := ""
+	prompt := &survey.Select{
+		Message: "Choose a region:",
+		Choices: []survey.Choice{
+			{Label: "US East", Value: "us-east-1", Description: "Virginia"},
+			{Label: "EU West", Value: "eu-west-1", Description: "Ireland"},
+			{Label: "AP South", Value: "ap-south-1", Disabled: true, DisabledReason: "not available on your plan"},
+		},
+	}
+	survey.AskOne(prompt, &region)
+*/
+type Choice struct {
+	Label          string
+	Value          string
+	Description    string
+	Disabled       bool
+	DisabledReason string
+	Group          string
+}
+
+// value returns what the answer for the choice carries.
+func (c Choice) value() string {
+	if c.Value == "" {
+		return c.Label
+	}
+	return c.Value
+}
+
+// choiceLabels returns the labels of the choices, which are shown as the options.
+func choiceLabels(choices []Choice) []string {
+	labels := []string{}
+	for _, choice := range choices {
+		labels = append(labels, choice.Label)
+	}
+	return labels
+}
+
+// choiceAnswer returns the answer for an option, carrying the value of its choice if
+// there is one.
+func choiceAnswer(choices []Choice, option core.OptionAnswer) core.OptionAnswer {
+	if option.Index < 0 || option.Index >= len(choices) {
+		return option
+	}
+	return core.OptionAnswer{Value: choices[option.Index].value(), Index: option.Index}
+}
+
+// choiceLabel returns the label of the option an answer is for.
+func choiceLabel(choices []Choice, answer core.OptionAnswer) string {
+	if answer.Index < 0 || answer.Index >= len(choices) {
+		return answer.Value
+	}
+	return choices[answer.Index].Label
+}
+
+// groupHeaders returns the group headers to show on a page of options, keyed by the
+// position of the option they go above. Headers are only shown for the options on the
+// page, so a group without any options left after filtering isn't shown at all.
+func groupHeaders(choices []Choice, page []core.OptionAnswer) map[int]string {
+	headers := map[int]string{}
+	previous := ""
+	for ix, option := range page {
+		group := ""
+		if option.Index >= 0 && option.Index < len(choices) {
+			group = choices[option.Index].Group
+		}
+		// every page starts with the header of its first option
+		if group != "" && (ix == 0 || group != previous) {
+			headers[ix] = group
+		}
+		previous = group
+	}
+	return headers
+}
+
+// groupRuns numbers the runs of options that are listed under the same header, so
+// options that are ranked can be kept under their own header. It returns nil if none
+// of the choices are in a group.
+func groupRuns(choices []Choice) []int {
+	runs := make([]int, len(choices))
+	grouped := false
+	for i, choice := range choices {
+		grouped = grouped || choice.Group != ""
+		if i > 0 {
+			runs[i] = runs[i-1]
+			if choice.Group != choices[i-1].Group {
+				runs[i]++
+			}
+		}
+	}
+	if !grouped {
+		return nil
+	}
+	return runs
+}
+
+// groupMembers returns the indices of the options that can be picked in the same
+// group as the given one.
+func groupMembers(choices []Choice, index int) []int {
+	if index < 0 || index >= len(choices) || choices[index].Group == "" {
+		return nil
+	}
+
+	members := []int{}
+	for i, choice := range choices {
+		if choice.Group == choices[index].Group && !choice.Disabled {
+			members = append(members, i)
+		}
+	}
+	return members
+}
+
+// isDisabled returns true if the option at the given index can't be picked.
+func isDisabled(choices []Choice, index int) bool {
+	return index >= 0 && index < len(choices) && choices[index].Disabled
+}
+
+// focusEnabled moves the focus through the options by step, wrapping around at the
+// ends, until it lands on an option that isn't disabled. A step of 0 keeps the focus
+// where it is unless that option is disabled.
+func focusEnabled(choices []Choice, options []core.OptionAnswer, selected int, step int) int {
+	if len(options) == 0 {
+		return selected
+	}
+	if step == 0 {
+		if selected < len(options) && !isDisabled(choices, options[selected].Index) {
+			return selected
+		}
+		step = 1
+	}
+
+	next := selected
+	for range options {
+		next = (next + step + len(options)) % len(options)
+		if !isDisabled(choices, options[next].Index) {
+			return next
+		}
+	}
+	// every option is disabled
+	return selected
+}
+
+// choiceDescription returns the description of the focused option.
+func choiceDescription(choices []Choice, options []core.OptionAnswer, selected int) string {
+	if selected < 0 || selected >= len(options) {
+		return ""
+	}
+	index := options[selected].Index
+	if index < 0 || index >= len(choices) {
+		return ""
+	}
+	return choices[index].Description
+}
+
+// findChoice looks up the option a supplied answer names, by its label or by the value
+// of its choice, and makes sure it can be picked.
+func findChoice(choices []Choice, options []string, value interface{}) (core.OptionAnswer, error) {
+	option, err := findOption(options, value)
+	if err != nil && len(choices) > 0 {
+		values := []string{}
+		for _, choice := range choices {
+			values = append(values, choice.value())
+		}
+		if byValue, valueErr := findOption(values, value); valueErr == nil {
+			option, err = byValue, nil
+		}
+	}
+	if err != nil {
+		return core.OptionAnswer{}, err
+	}
+
+	if isDisabled(choices, option.Index) {
+		return core.OptionAnswer{}, fmt.Errorf("%q is disabled", choices[option.Index].Label)
+	}
+	return choiceAnswer(choices, option), nil
+}
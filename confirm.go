@@ -3,6 +3,7 @@ package survey
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 )
 
 // Confirm is a regular text input that accept yes/no answers. Response type is a bool.
@@ -11,6 +12,8 @@ type Confirm struct {
 	Message string
 	Default bool
 	Help    string
+	// the answer given last time, when going back to the question
+	previous *bool
 }
 
 // data available to the templates when processing
@@ -70,45 +73,45 @@ func (c *Confirm) getBool(showHelp bool, config *PromptConfig) (bool, error) {
 		case noRx.Match([]byte(val)):
 			answer = false
 		case val == "":
-			answer = c.Default
+			answer = c.prefilled().Default
 		case val == config.HelpInput && c.Help != "":
 			err := c.Render(
 				ConfirmQuestionTemplate,
 				ConfirmTemplateData{
-					Confirm:  *c,
+					Confirm:  c.prefilled(),
 					ShowHelp: true,
 					Config:   config,
 				},
 			)
 			if err != nil {
 				// use the default value and bubble up
-				return c.Default, err
+				return c.prefilled().Default, err
 			}
 			showHelp = true
 			continue
 		default:
 			// we didnt get a valid answer, so print error and prompt again
 			if err := c.Error(config, fmt.Errorf("%q is not a valid answer, please try again.", val)); err != nil {
-				return c.Default, err
+				return c.prefilled().Default, err
 			}
 			err := c.Render(
 				ConfirmQuestionTemplate,
 				ConfirmTemplateData{
-					Confirm:  *c,
+					Confirm:  c.prefilled(),
 					ShowHelp: showHelp,
 					Config:   config,
 				},
 			)
 			if err != nil {
 				// use the default value and bubble up
-				return c.Default, err
+				return c.prefilled().Default, err
 			}
 			continue
 		}
 		return answer, nil
 	}
 	// should not get here
-	return c.Default, nil
+	return c.prefilled().Default, nil
 }
 
 /*
@@ -124,7 +127,7 @@ func (c *Confirm) Prompt(config *PromptConfig) (interface{}, error) {
 	err := c.Render(
 		ConfirmQuestionTemplate,
 		ConfirmTemplateData{
-			Confirm: *c,
+			Confirm: c.prefilled(),
 			Config:  config,
 		},
 	)
@@ -136,6 +139,50 @@ func (c *Confirm) Prompt(config *PromptConfig) (interface{}, error) {
 	return c.getBool(false, config)
 }
 
+// DefaultAnswer returns the answer the user would get by just pressing enter.
+func (c *Confirm) DefaultAnswer() (interface{}, error) {
+	return c.prefilled().Default, nil
+}
+
+// ConvertAnswer turns a supplied answer into a bool. Besides a bool, the answer can
+// be anything the user could type, such as "y" or "no".
+func (c *Confirm) ConvertAnswer(value interface{}) (interface{}, error) {
+	switch val := value.(type) {
+	case bool:
+		return val, nil
+	case string:
+		switch {
+		case yesRx.MatchString(val):
+			return true, nil
+		case noRx.MatchString(val):
+			return false, nil
+		}
+		if b, err := strconv.ParseBool(val); err == nil {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot use %v as a yes or no answer", value)
+}
+
+// prefill makes an earlier answer the default when going back to the question, or
+// goes back to Default when there is none.
+func (c *Confirm) prefill(ans interface{}) {
+	c.previous = nil
+	if val, ok := ans.(bool); ok {
+		c.previous = &val
+	}
+}
+
+// prefilled is the prompt as the user sees it, with the earlier answer in place of
+// Default.
+func (c *Confirm) prefilled() Confirm {
+	prompt := *c
+	if c.previous != nil {
+		prompt.Default = *c.previous
+	}
+	return prompt
+}
+
 // Cleanup overwrite the line with the finalized formatted version
 func (c *Confirm) Cleanup(config *PromptConfig, val interface{}) error {
 	// if the value was previously true
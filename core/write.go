@@ -1,6 +1,7 @@
 package core
 
 import (
+	"encoding"
 	"errors"
 	"fmt"
 	"reflect"
@@ -51,25 +52,41 @@ func WriteAnswer(t interface{}, name string, v interface{}) (err error) {
 	// the object "inside" of the target pointer
 	elem := target.Elem()
 
+	// if the name points to something nested inside of the target
+	if parent, rest, ok := splitPath(elem, name); ok {
+		// find the place to write the rest of the path to
+		nested, err := nestedTarget(elem, parent)
+		if err == nil {
+			err = WriteAnswer(nested, rest, v)
+		}
+		// report the whole name if part of the path doesn't exist
+		if _, ok := IsFieldNotMatch(err); ok {
+			return errFieldNotMatch{name}
+		}
+		if convErr, ok := err.(*ConversionError); ok {
+			convErr.Name = name
+		}
+		return err
+	}
+
 	// handle the special types
 	switch elem.Kind() {
 	// if we are writing to a struct
 	case reflect.Struct:
-		// if we are writing to an option answer than we want to treat
-		// it like a single thing and not a place to deposit answers
-		if elem.Type().Name() == "OptionAnswer" {
+		// if we are writing to an option answer or something that reads itself from
+		// text than we want to treat it like a single thing and not a place to deposit answers
+		if isSingleValue(elem.Type()) {
 			// copy the value over to the normal struct
-			return copy(elem, value)
+			return copyAnswer(name, elem, value)
 		}
 
-		// get the name of the field that matches the string we  were given
-		fieldIndex, err := findFieldIndex(elem, name)
+		// get the field that matches the string we were given
+		field, structField, err := findField(elem, name)
 		// if something went wrong
 		if err != nil {
 			// bubble up
 			return err
 		}
-		field := elem.Field(fieldIndex)
 		// handle references to the Settable interface aswell
 		if s, ok := field.Interface().(Settable); ok {
 			// use the interface method
@@ -82,19 +99,150 @@ func WriteAnswer(t interface{}, name string, v interface{}) (err error) {
 			}
 		}
 
+		// times are parsed with the layout the field asks for
+		if layout := structField.Tag.Get("layout"); layout != "" && value.Kind() == reflect.String && isTime(field.Type()) {
+			parsed, err := time.Parse(layout, value.String())
+			if err != nil {
+				return &ConversionError{Name: name, Type: field.Type(), Err: err}
+			}
+			value = reflect.ValueOf(parsed)
+		}
+
 		// copy the value over to the normal struct
-		return copy(field, value)
+		return copyAnswer(name, field, value)
 	case reflect.Map:
-		mapType := reflect.TypeOf(t).Elem()
-		if mapType.Key().Kind() != reflect.String || mapType.Elem().Kind() != reflect.Interface {
-			return errors.New("answer maps must be of type map[string]interface")
+		// an answer that is a map itself replaces the whole map when it isn't named,
+		// like the answer to a single question
+		if name == "" && value.Kind() == reflect.Map {
+			return copyAnswer(name, elem, value)
 		}
-		mt := *t.(*map[string]interface{})
-		mt[name] = value.Interface()
-		return nil
+		return writeMapEntry(name, elem, value)
 	}
 	// otherwise just copy the value to the target
-	return copy(elem, value)
+	return copyAnswer(name, elem, value)
+}
+
+// PreviousAnswer is what a target held for a question before an answer was written to
+// it, so the answer can be taken back out again.
+type PreviousAnswer struct {
+	// the value the answer is written to, or the map holding it
+	slot reflect.Value
+	key  reflect.Value
+	// what was there before, which is invalid for keys that weren't in the map
+	value reflect.Value
+}
+
+// RememberAnswer returns what the target holds for the question with the given name,
+// to be put back with Restore. Nothing is remembered for custom types, which have to
+// be told about every answer as it comes in.
+func RememberAnswer(t interface{}, name string) (PreviousAnswer, error) {
+	if _, ok := t.(Settable); ok {
+		return PreviousAnswer{}, nil
+	}
+
+	target := reflect.ValueOf(t)
+	if target.Kind() != reflect.Ptr {
+		return PreviousAnswer{}, errors.New("you must pass a pointer as the target of a Write operation")
+	}
+	elem := target.Elem()
+
+	// look for the answer where WriteAnswer would put it
+	if parent, rest, ok := splitPath(elem, name); ok {
+		nested, err := nestedTarget(elem, parent)
+		if err != nil {
+			return PreviousAnswer{}, err
+		}
+		return RememberAnswer(nested, rest)
+	}
+
+	slot := elem
+	switch elem.Kind() {
+	case reflect.Struct:
+		if !isSingleValue(elem.Type()) {
+			field, _, err := findField(elem, name)
+			if err != nil {
+				return PreviousAnswer{}, err
+			}
+			slot = field
+		}
+	case reflect.Map:
+		// a missing map or an unnamed answer replaces the whole map, otherwise only
+		// the entry changes
+		if !elem.IsNil() && name != "" && elem.Type().Key().Kind() == reflect.String {
+			key := reflect.ValueOf(name).Convert(elem.Type().Key())
+			return PreviousAnswer{slot: elem, key: key, value: elem.MapIndex(key)}, nil
+		}
+	}
+
+	value := reflect.New(slot.Type()).Elem()
+	value.Set(slot)
+	return PreviousAnswer{slot: slot, value: value}, nil
+}
+
+// Restore puts back what the target held before the answer was written.
+func (p PreviousAnswer) Restore() {
+	switch {
+	case !p.slot.IsValid():
+		// there was nothing to remember
+	case p.key.IsValid():
+		// an invalid value removes the key again
+		p.slot.SetMapIndex(p.key, p.value)
+	default:
+		p.slot.Set(p.value)
+	}
+}
+
+// writeMapEntry writes the answer to the key with the given name, converting it to the
+// type of the values in the map.
+func writeMapEntry(name string, elem reflect.Value, value reflect.Value) error {
+	mapType := elem.Type()
+	if mapType.Key().Kind() != reflect.String {
+		return errors.New("answer maps must have string keys")
+	}
+	if elem.IsNil() {
+		elem.Set(reflect.MakeMap(mapType))
+	}
+
+	entry := reflect.New(mapType.Elem()).Elem()
+	if value.IsValid() {
+		if err := copyEntry(entry, value); err != nil {
+			return &ConversionError{Name: name, Type: mapType.Elem(), Err: err}
+		}
+	}
+
+	elem.SetMapIndex(reflect.ValueOf(name).Convert(mapType.Key()), entry)
+	return nil
+}
+
+// ConversionError is returned by WriteAnswer when an answer can't be converted to
+// the type of the value it is written to.
+type ConversionError struct {
+	// Name is the name of the question that was answered
+	Name string
+	// Type is the type the answer had to be converted to
+	Type reflect.Type
+	// Err is the reason the conversion failed
+	Err error
+}
+
+func (err *ConversionError) Error() string {
+	if err.Name == "" {
+		return fmt.Sprintf("could not convert answer to %v: %v", err.Type, err.Err)
+	}
+	return fmt.Sprintf("could not convert answer for %q to %v: %v", err.Name, err.Type, err.Err)
+}
+
+// Unwrap returns the reason the conversion failed.
+func (err *ConversionError) Unwrap() error {
+	return err.Err
+}
+
+// copyAnswer copies the answer to the target, reporting a failure as a ConversionError.
+func copyAnswer(name string, t reflect.Value, v reflect.Value) error {
+	if err := copy(t, v); err != nil {
+		return &ConversionError{Name: name, Type: t.Type(), Err: err}
+	}
+	return nil
 }
 
 type errFieldNotMatch struct {
@@ -136,6 +284,157 @@ func IsFieldNotMatch(err error) (string, bool) {
 	return "", false
 }
 
+// splitPath splits a dotted name like "db.host" into the name of the field or key
+// holding the nested value and the rest of the path. Fields of a struct whose name
+// or tag match the whole name are written to directly.
+func splitPath(elem reflect.Value, name string) (string, string, bool) {
+	dot := strings.Index(name, ".")
+	if dot < 0 {
+		return "", "", false
+	}
+
+	switch elem.Kind() {
+	case reflect.Struct:
+		// some structs are a single thing and not a place to deposit answers
+		if isSingleValue(elem.Type()) {
+			return "", "", false
+		}
+		// if there's a field with the whole name use it
+		if _, _, err := findField(elem, name); err == nil {
+			return "", "", false
+		}
+	case reflect.Map:
+		// the nested values of maps are in their own map, unless the map can't hold
+		// one in which case the whole name is the key
+		switch elem.Type().Elem().Kind() {
+		case reflect.Interface, reflect.Map, reflect.Ptr:
+		default:
+			return "", "", false
+		}
+	default:
+		return "", "", false
+	}
+
+	return name[:dot], name[dot+1:], true
+}
+
+// nestedTarget returns a pointer to the value with the given name inside of the struct
+// or map, allocating it along the way if it doesn't exist yet.
+func nestedTarget(elem reflect.Value, name string) (interface{}, error) {
+	switch elem.Kind() {
+	case reflect.Struct:
+		field, _, err := findField(elem, name)
+		if err != nil {
+			return nil, err
+		}
+
+		switch field.Kind() {
+		// pointers already point to the place to write to
+		case reflect.Ptr:
+			if field.IsNil() {
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+			return field.Interface(), nil
+		case reflect.Map:
+			if field.IsNil() {
+				field.Set(reflect.MakeMap(field.Type()))
+			}
+		}
+		return field.Addr().Interface(), nil
+	case reflect.Map:
+		if elem.Type().Key().Kind() != reflect.String {
+			return nil, errors.New("answer maps must have string keys")
+		}
+		if elem.IsNil() {
+			elem.Set(reflect.MakeMap(elem.Type()))
+		}
+
+		// maps of maps and pointers hold the nested values themselves
+		switch valueType := elem.Type().Elem(); valueType.Kind() {
+		case reflect.Map, reflect.Ptr:
+			key := reflect.ValueOf(name).Convert(elem.Type().Key())
+			nested := elem.MapIndex(key)
+			if !nested.IsValid() || nested.IsNil() {
+				if valueType.Kind() == reflect.Map {
+					nested = reflect.MakeMap(valueType)
+				} else {
+					nested = reflect.New(valueType.Elem())
+				}
+				elem.SetMapIndex(key, nested)
+			}
+			if valueType.Kind() == reflect.Ptr {
+				return nested.Interface(), nil
+			}
+			// the map we write to is the same one as the one in the parent
+			ptr := reflect.New(valueType)
+			ptr.Elem().Set(nested)
+			return ptr.Interface(), nil
+		}
+
+		mt, ok := elem.Interface().(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot write to %v inside of a %s", name, elem.Type())
+		}
+
+		switch nested := mt[name].(type) {
+		// create the nested map if it isn't there yet
+		case nil:
+			created := map[string]interface{}{}
+			mt[name] = created
+			return &created, nil
+		case map[string]interface{}:
+			return &nested, nil
+		}
+		// anything else has to be a pointer we can write through
+		if reflect.ValueOf(mt[name]).Kind() == reflect.Ptr {
+			return mt[name], nil
+		}
+		return nil, fmt.Errorf("cannot write to %v inside of a %T", name, mt[name])
+	}
+
+	return nil, fmt.Errorf("cannot write to %v inside of a %s", name, elem.Kind())
+}
+
+// findField returns the field of the struct matching the name, looking through
+// embedded structs if the struct doesn't have one itself. Embedded pointers are only
+// allocated if they hold the field.
+func findField(s reflect.Value, name string) (reflect.Value, reflect.StructField, error) {
+	fieldIndex, err := findFieldIndex(s, name)
+	if err == nil {
+		return s.Field(fieldIndex), s.Type().Field(fieldIndex), nil
+	}
+
+	// look for the field in the embedded structs
+	for i := 0; i < s.NumField(); i++ {
+		if !s.Type().Field(i).Anonymous {
+			continue
+		}
+		embedded := s.Field(i)
+
+		switch {
+		case embedded.Kind() == reflect.Struct:
+			if field, structField, err := findField(embedded, name); err == nil {
+				return field, structField, nil
+			}
+		case embedded.Kind() == reflect.Ptr && embedded.Type().Elem().Kind() == reflect.Struct:
+			inner := embedded
+			if embedded.IsNil() {
+				inner = reflect.New(embedded.Type().Elem())
+			}
+			if field, structField, err := findField(inner.Elem(), name); err == nil {
+				// hold on to the struct we found it in
+				if embedded.IsNil() {
+					embedded.Set(inner)
+				}
+				return field, structField, nil
+			}
+		}
+	}
+
+	// we didn't find the field
+	return reflect.Value{}, reflect.StructField{}, err
+}
+
 // BUG(AlecAivazis): the current implementation might cause weird conflicts if there are
 // two fields with same name that only differ by casing.
 func findFieldIndex(s reflect.Value, name string) (int, error) {
@@ -197,13 +496,41 @@ func copy(t reflect.Value, v reflect.Value) (err error) {
 		}
 	}()
 
+	// if we are writing to a pointer to something else, allocate a new value to write to
+	if t.Kind() == reflect.Ptr && !v.Type().AssignableTo(t.Type()) {
+		ptr := reflect.New(t.Type().Elem())
+		if err := copy(ptr.Elem(), v); err != nil {
+			return err
+		}
+		t.Set(ptr)
+		return
+	}
+
 	// if we are copying from a string result to something else
 	if v.Kind() == reflect.String && v.Type() != t.Type() {
 		var castVal interface{}
 		var casterr error
-		vString := v.Interface().(string)
+		vString := v.String()
+
+		// times are parsed as RFC 3339 unless their field has a layout tag
+		if isTime(t.Type()) {
+			castVal, casterr = time.Parse(time.RFC3339, vString)
+			if casterr != nil {
+				return casterr
+			}
+			t.Set(reflect.ValueOf(castVal))
+			return
+		}
+
+		// let types that know how to read themselves from text do so
+		if u, ok := textUnmarshaler(t); ok {
+			return u.UnmarshalText([]byte(vString))
+		}
 
 		switch t.Kind() {
+		case reflect.String:
+			t.SetString(vString)
+			return
 		case reflect.Bool:
 			castVal, casterr = strconv.ParseBool(vString)
 		case reflect.Int:
@@ -278,8 +605,50 @@ func copy(t reflect.Value, v reflect.Value) (err error) {
 		return
 	}
 
+	// if we are copying from one kind of number to another
+	if isNumber(v) && v.Type() != t.Type() {
+		// numbers written to text are formatted
+		if t.Kind() == reflect.String {
+			t.SetString(fmt.Sprint(v.Interface()))
+			return
+		}
+		if isNumber(t) {
+			converted := v.Convert(t.Type())
+			// make sure the number fits in the target
+			if converted.Convert(v.Type()).Interface() != v.Interface() || (isNegative(v) && isUnsigned(t)) {
+				return fmt.Errorf("%v does not fit in a %s", v.Interface(), t.Type())
+			}
+			t.Set(converted)
+			return
+		}
+	}
+
+	// if we are copying from one map to another
+	if v.Kind() == reflect.Map && t.Kind() == reflect.Map {
+		// start from an empty map so writing an answer again replaces the old one
+		t.Set(reflect.MakeMapWithSize(t.Type(), v.Len()))
+		iter := v.MapRange()
+		for iter.Next() {
+			key := reflect.New(t.Type().Key()).Elem()
+			if err := copy(key, iter.Key()); err != nil {
+				return err
+			}
+			val := reflect.New(t.Type().Elem()).Elem()
+			if err := copyEntry(val, iter.Value()); err != nil {
+				return err
+			}
+			t.SetMapIndex(key, val)
+		}
+		return
+	}
+
 	// if we are copying from an OptionAnswer to something
 	if v.Type().Name() == "OptionAnswer" {
+		// copying an option answer to something that reads its value from text
+		if u, ok := textUnmarshaler(t); ok {
+			return u.UnmarshalText([]byte(v.FieldByName("Value").String()))
+		}
+
 		// copying an option answer to a string
 		if t.Kind() == reflect.String {
 			// copies the Value field of the struct
@@ -306,6 +675,10 @@ func copy(t reflect.Value, v reflect.Value) (err error) {
 
 	// if we are copying from one slice or array to another
 	if isList(v) && isList(t) {
+		// start from an empty slice so writing an answer again replaces the old one
+		if t.Kind() == reflect.Slice {
+			t.Set(reflect.MakeSlice(t.Type(), 0, v.Len()))
+		}
 		// loop over every item in the desired value
 		for i := 0; i < v.Len(); i++ {
 			// write to the target given its kind
@@ -336,3 +709,78 @@ func copy(t reflect.Value, v reflect.Value) (err error) {
 	// we're done
 	return
 }
+
+// copyEntry copies a value out of a map or into a map of interfaces, which holds any
+// value as it is.
+func copyEntry(t reflect.Value, v reflect.Value) error {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if t.Kind() == reflect.Interface && v.Type().AssignableTo(t.Type()) {
+		t.Set(v)
+		return nil
+	}
+	return copy(t, v)
+}
+
+// isNumber returns true if the value is an integer or a floating point number
+func isNumber(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// isNegative returns true if the value is a number below zero
+func isNegative(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() < 0
+	default:
+		return false
+	}
+}
+
+// isTime returns true if the type is a time.Time or a pointer to one
+func isTime(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t == reflect.TypeOf(time.Time{})
+}
+
+// isUnsigned returns true if the value is an unsigned integer
+func isUnsigned(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// isSingleValue returns true for the structs that hold a single answer, which are option
+// answers and types that read themselves from text like time.Time.
+func isSingleValue(t reflect.Type) bool {
+	return t.Name() == "OptionAnswer" || reflect.PtrTo(t).Implements(textUnmarshalerType)
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// textUnmarshaler returns the target as an encoding.TextUnmarshaler if it is one.
+func textUnmarshaler(t reflect.Value) (encoding.TextUnmarshaler, bool) {
+	if !t.CanAddr() {
+		return nil, false
+	}
+	u, ok := t.Addr().Interface().(encoding.TextUnmarshaler)
+	return u, ok
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"net"
 	"reflect"
 	"testing"
 	"time"
@@ -655,3 +656,315 @@ func check(t *testing.T, err error) {
 		t.Fatalf("Encountered error while writing answer: %v", err.Error())
 	}
 }
+
+func TestWrite_overwritesSlice(t *testing.T) {
+	// a slice that already holds an answer
+	ptr := []string{"hello"}
+
+	// write a new answer over it
+	err := WriteAnswer(&ptr, "", []string{"goodbye", "world"})
+	assert.Nil(t, err)
+
+	// make sure the old answer is gone
+	assert.Equal(t, []string{"goodbye", "world"}, ptr)
+}
+
+func TestWriteAnswer_nestedStruct(t *testing.T) {
+	type DB struct {
+		Host string
+		Port int `survey:"port_number"`
+	}
+	ptr := struct {
+		DB  DB `survey:"db"`
+		Log *struct{ Level string }
+	}{}
+
+	check(t, WriteAnswer(&ptr, "db.host", "localhost"))
+	check(t, WriteAnswer(&ptr, "db.port_number", "5432"))
+	check(t, WriteAnswer(&ptr, "log.level", "debug"))
+
+	assert.Equal(t, DB{Host: "localhost", Port: 5432}, ptr.DB)
+	// the pointer should have been allocated along the way
+	if assert.NotNil(t, ptr.Log) {
+		assert.Equal(t, "debug", ptr.Log.Level)
+	}
+}
+
+func TestWriteAnswer_prefersWholeName(t *testing.T) {
+	ptr := struct {
+		Host string `survey:"db.host"`
+	}{}
+
+	check(t, WriteAnswer(&ptr, "db.host", "localhost"))
+	assert.Equal(t, "localhost", ptr.Host)
+}
+
+func TestWriteAnswer_embeddedStruct(t *testing.T) {
+	type Base struct{ Name string }
+	type Extra struct{ Color string }
+	ptr := struct {
+		Base
+		*Extra
+		Age int
+	}{}
+
+	check(t, WriteAnswer(&ptr, "name", "Larry Bird"))
+	check(t, WriteAnswer(&ptr, "age", 32))
+	assert.Equal(t, "Larry Bird", ptr.Name)
+	assert.Equal(t, 32, ptr.Age)
+	// embedded pointers are left alone unless they hold the field
+	assert.Nil(t, ptr.Extra)
+
+	check(t, WriteAnswer(&ptr, "color", "blue"))
+	if assert.NotNil(t, ptr.Extra) {
+		assert.Equal(t, "blue", ptr.Color)
+	}
+}
+
+func TestWriteAnswer_nestedMap(t *testing.T) {
+	ptr := map[string]interface{}{}
+
+	check(t, WriteAnswer(&ptr, "db.host", "localhost"))
+	check(t, WriteAnswer(&ptr, "db.port", 5432))
+	check(t, WriteAnswer(&ptr, "db.auth.user", "admin"))
+
+	assert.Equal(t, map[string]interface{}{
+		"db": map[string]interface{}{
+			"host": "localhost",
+			"port": 5432,
+			"auth": map[string]interface{}{"user": "admin"},
+		},
+	}, ptr)
+
+	// values that aren't maps can't hold nested answers
+	assert.NotNil(t, WriteAnswer(&ptr, "db.host.name", "localhost"))
+}
+
+func TestWriteAnswer_nestedFieldNotFound(t *testing.T) {
+	ptr := struct {
+		DB struct{ Host string }
+	}{}
+
+	for _, name := range []string{"db.port", "cache.host"} {
+		err := WriteAnswer(&ptr, name, "")
+		fieldName, ok := IsFieldNotMatch(err)
+		assert.True(t, ok, name)
+		assert.Equal(t, name, fieldName)
+	}
+}
+
+// a type that reads itself from text
+type level int
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level %q", text)
+	}
+	return nil
+}
+
+func TestWrite_textUnmarshaler(t *testing.T) {
+	ptr := struct {
+		IP     net.IP
+		Level  level
+		Levels []level
+	}{}
+
+	check(t, WriteAnswer(&ptr, "ip", "127.0.0.1"))
+	check(t, WriteAnswer(&ptr, "level", OptionAnswer{Value: "high", Index: 0}))
+	check(t, WriteAnswer(&ptr, "levels", []string{"low", "high"}))
+
+	assert.Equal(t, "127.0.0.1", ptr.IP.String())
+	assert.Equal(t, level(2), ptr.Level)
+	assert.Equal(t, []level{1, 2}, ptr.Levels)
+
+	assert.NotNil(t, WriteAnswer(&ptr, "level", "medium"))
+}
+
+func TestWrite_time(t *testing.T) {
+	ptr := time.Time{}
+	check(t, WriteAnswer(&ptr, "", "2019-06-01T10:30:00Z"))
+	assert.Equal(t, time.Date(2019, 6, 1, 10, 30, 0, 0, time.UTC), ptr)
+
+	// fields can ask for a layout of their own
+	dates := struct {
+		Start time.Time  `layout:"2006-01-02"`
+		End   *time.Time `layout:"02/01/2006"`
+		Due   time.Time
+	}{}
+	check(t, WriteAnswer(&dates, "start", "2020-02-29"))
+	check(t, WriteAnswer(&dates, "end", "01/03/2020"))
+	check(t, WriteAnswer(&dates, "due", "2020-03-02T09:00:00Z"))
+	assert.Equal(t, time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC), dates.Start)
+	if assert.NotNil(t, dates.End) {
+		assert.Equal(t, time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), *dates.End)
+	}
+	assert.Equal(t, time.Date(2020, 3, 2, 9, 0, 0, 0, time.UTC), dates.Due)
+
+	err := WriteAnswer(&dates, "start", "2020-02-29T10:30:00Z")
+	if assert.IsType(t, &ConversionError{}, err) {
+		assert.Equal(t, "start", err.(*ConversionError).Name)
+	}
+}
+
+func TestWrite_allocatesPointers(t *testing.T) {
+	ptr := struct {
+		Name  *string
+		Age   *int
+		Color *string
+	}{}
+
+	check(t, WriteAnswer(&ptr, "name", "Larry Bird"))
+	check(t, WriteAnswer(&ptr, "age", "32"))
+	check(t, WriteAnswer(&ptr, "color", OptionAnswer{Value: "blue", Index: 1}))
+
+	if assert.NotNil(t, ptr.Name) {
+		assert.Equal(t, "Larry Bird", *ptr.Name)
+	}
+	if assert.NotNil(t, ptr.Age) {
+		assert.Equal(t, 32, *ptr.Age)
+	}
+	if assert.NotNil(t, ptr.Color) {
+		assert.Equal(t, "blue", *ptr.Color)
+	}
+}
+
+func TestWrite_namedString(t *testing.T) {
+	type color string
+	ptr := color("")
+
+	check(t, WriteAnswer(&ptr, "", "blue"))
+	assert.Equal(t, color("blue"), ptr)
+}
+
+func TestWrite_conversionError(t *testing.T) {
+	ptr := struct {
+		DB struct{ Port int }
+	}{}
+
+	err := WriteAnswer(&ptr, "db.port", "http")
+	if assert.IsType(t, &ConversionError{}, err) {
+		convErr := err.(*ConversionError)
+		assert.Equal(t, "db.port", convErr.Name)
+		assert.Equal(t, reflect.TypeOf(0), convErr.Type)
+		assert.NotNil(t, convErr.Unwrap())
+		assert.Equal(t, `could not convert answer for "db.port" to int: `+convErr.Unwrap().Error(), convErr.Error())
+	}
+}
+
+func TestWrite_convertsNumbers(t *testing.T) {
+	ptr := struct {
+		Age     int
+		Small   int8
+		Ratio   float32
+		Count   uint
+		Display string
+	}{}
+
+	check(t, WriteAnswer(&ptr, "age", int64(32)))
+	check(t, WriteAnswer(&ptr, "ratio", 0.5))
+	check(t, WriteAnswer(&ptr, "count", int64(3)))
+	check(t, WriteAnswer(&ptr, "display", int64(42)))
+
+	assert.Equal(t, 32, ptr.Age)
+	assert.Equal(t, float32(0.5), ptr.Ratio)
+	assert.Equal(t, uint(3), ptr.Count)
+	assert.Equal(t, "42", ptr.Display)
+
+	// numbers that don't fit are reported
+	assert.NotNil(t, WriteAnswer(&ptr, "small", int64(300)))
+	assert.NotNil(t, WriteAnswer(&ptr, "age", 2.5))
+	assert.NotNil(t, WriteAnswer(&ptr, "count", int64(-1)))
+}
+
+func TestWriteAnswer_typedMaps(t *testing.T) {
+	words := map[string]string{}
+	check(t, WriteAnswer(&words, "name", "world"))
+	assert.Equal(t, map[string]string{"name": "world"}, words)
+
+	// answers are converted to the type of the values
+	var numbers map[string]int
+	check(t, WriteAnswer(&numbers, "port", "5432"))
+	check(t, WriteAnswer(&numbers, "replicas", 3))
+	assert.Equal(t, map[string]int{"port": 5432, "replicas": 3}, numbers)
+
+	err := WriteAnswer(&numbers, "port", "many")
+	if assert.IsType(t, &ConversionError{}, err) {
+		assert.Equal(t, "port", err.(*ConversionError).Name)
+	}
+
+	// dotted names are keys of their own in maps that can't hold nested ones
+	check(t, WriteAnswer(&words, "db.host", "localhost"))
+	assert.Equal(t, "localhost", words["db.host"])
+
+	// and nested maps of other types are created
+	nested := map[string]map[string]string{}
+	check(t, WriteAnswer(&nested, "db.host", "localhost"))
+	assert.Equal(t, map[string]map[string]string{"db": {"host": "localhost"}}, nested)
+}
+
+func TestWriteAnswer_mapAnswers(t *testing.T) {
+	answer := map[string]string{"env": "prod", "replicas": "3"}
+
+	// an answer without a name replaces the whole map
+	labels := map[string]string{"old": "value"}
+	check(t, WriteAnswer(&labels, "", answer))
+	assert.Equal(t, answer, labels)
+
+	var counts map[string]int
+	check(t, WriteAnswer(&counts, "", map[string]string{"replicas": "3"}))
+	assert.Equal(t, map[string]int{"replicas": 3}, counts)
+
+	// a named one is stored under its name
+	answers := map[string]interface{}{}
+	check(t, WriteAnswer(&answers, "labels", answer))
+	assert.Equal(t, map[string]interface{}{"labels": answer}, answers)
+
+	// and copied to fields of any map type
+	ptr := struct {
+		Labels map[string]string
+		Any    map[string]interface{}
+	}{}
+	check(t, WriteAnswer(&ptr, "labels", answer))
+	check(t, WriteAnswer(&ptr, "any", answer))
+	assert.Equal(t, answer, ptr.Labels)
+	assert.Equal(t, map[string]interface{}{"env": "prod", "replicas": "3"}, ptr.Any)
+
+	// writing the answer again replaces the old one
+	check(t, WriteAnswer(&ptr, "labels", map[string]string{"env": "dev"}))
+	assert.Equal(t, map[string]string{"env": "dev"}, ptr.Labels)
+}
+
+func TestRememberAnswer_restoresField(t *testing.T) {
+	shared := &net.IPAddr{}
+	response := struct {
+		Name  string
+		Other *net.IPAddr
+	}{Name: "before", Other: shared}
+
+	previous, err := RememberAnswer(&response, "name")
+	assert.Nil(t, err)
+	assert.Nil(t, WriteAnswer(&response, "name", "after"))
+	assert.Equal(t, "after", response.Name)
+
+	previous.Restore()
+	assert.Equal(t, "before", response.Name)
+	// fields that weren't answered are left alone
+	assert.True(t, response.Other == shared)
+}
+
+func TestRememberAnswer_removesNewMapEntry(t *testing.T) {
+	response := map[string]interface{}{"kept": 1}
+
+	previous, err := RememberAnswer(&response, "added")
+	assert.Nil(t, err)
+	assert.Nil(t, WriteAnswer(&response, "added", 2))
+
+	previous.Restore()
+	assert.Equal(t, map[string]interface{}{"kept": 1}, response)
+}
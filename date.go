@@ -0,0 +1,419 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+	"unicode"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+/*
+Date is a prompt that lets the user pick a day from a calendar. The left and right
+arrows move by a day, up and down by a week and page up and page down by a month.
+The user can also type a date in Layout, or one relative to today like "+3d", "-2w",
+"+1m" or "+1y". Response type is a time.Time.
+
+	start := time.Time{}
+	prompt := &survey.Date{
+		Message: "When should the maintenance start?",
+		Min:     time.Now(),
+	}
+	survey.AskOne(prompt, &start)
+*/
+type Date struct {
+	Renderer
+	Message     string
+	Default     time.Time
+	Min         time.Time
+	Max         time.Time
+	Layout      string
+	WeekStart   time.Weekday
+	Help        string
+	selected    time.Time
+	input       string
+	showingHelp bool
+	// the answer given last time, when going back to the question
+	previous *time.Time
+}
+
+// CalendarDay is a single day shown in the calendar of a Date prompt. Days that
+// only pad out the first and last weeks of the month have a Day of 0.
+type CalendarDay struct {
+	Day      int
+	Selected bool
+	Disabled bool
+}
+
+// DateTemplateData is the data available to the templates when processing
+type DateTemplateData struct {
+	Date
+	Input      string
+	Month      string
+	Weekdays   []string
+	Weeks      [][]CalendarDay
+	Answer     string
+	ShowAnswer bool
+	ShowHelp   bool
+	Config     *PromptConfig
+}
+
+// DefaultDateLayout is the layout used by Date prompts that don't have their own.
+var DefaultDateLayout = "2006-01-02"
+
+var DateQuestionTemplate = `
+{{- if .ShowHelp }}{{- color .Config.Icons.Help.Format }}{{ .Config.Icons.Help.Text }} {{ .Help }}{{color "reset"}}{{"\n"}}{{end}}
+{{- color .Config.Icons.Question.Format }}{{ .Config.Icons.Question.Text }} {{color "reset"}}
+{{- color "default+hb"}}{{ .Message }} {{color "reset"}}
+{{- if .ShowAnswer}}
+  {{- color "cyan"}}{{.Answer}}{{color "reset"}}{{"\n"}}
+{{- else }}
+  {{- color "cyan"}}[Use arrows to move, type a date{{- if and .Help (not .ShowHelp)}}, {{ .Config.HelpInput }} for more help{{end}}]{{color "reset"}}
+  {{- if .Input}} {{ .Input }}{{end}}{{"\n"}}
+  {{- "  "}}{{ .Month }}{{"\n"}}
+  {{- "  "}}{{- range .Weekdays}} {{.}} {{end}}{{"\n"}}
+  {{- range .Weeks}}
+    {{- "  "}}
+    {{- range .}}
+      {{- if not .Day}}{{"    "}}
+      {{- else if .Selected}}{{color $.Config.Icons.SelectFocus.Format }}[{{printf "%2d" .Day}}]{{color "reset"}}
+      {{- else if .Disabled}}{{color "black+h"}} {{printf "%2d" .Day}} {{color "reset"}}
+      {{- else}} {{printf "%2d" .Day}} {{end}}
+    {{- end}}{{"\n"}}
+  {{- end}}
+{{- end}}`
+
+// the dates relative to today the user can type
+var relativeDateRx = regexp.MustCompile(`^([+-]\d+)([dwmy])$`)
+
+func (d *Date) Prompt(config *PromptConfig) (interface{}, error) {
+	// start from the default
+	start, err := d.start()
+	if err != nil {
+		return nil, err
+	}
+	d.selected = start
+	d.input = ""
+	d.showingHelp = false
+
+	cursor := d.NewCursor()
+	cursor.Hide()       // hide the cursor
+	defer cursor.Show() // show the cursor when we're done
+
+	// ask the question
+	err = d.render(config)
+	if err != nil {
+		return nil, err
+	}
+
+	rr := d.NewRuneReader()
+	rr.SetTermMode()
+	defer rr.RestoreTermMode()
+
+	// start waiting for input
+	for {
+		r, _, err := rr.ReadRune()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case r == terminal.KeyInterrupt:
+			return nil, terminal.InterruptErr
+		case r == terminal.SpecialKeyShiftTab:
+			return nil, terminal.GoBackErr
+		case r == terminal.KeyEnter || r == '\n' || r == terminal.KeyEndTransmission:
+			ans, invalid := d.answer()
+			// if the user picked a date we can use we're done
+			if invalid == nil {
+				return ans, nil
+			}
+			// otherwise tell them what's wrong and let them fix it
+			if err := d.Error(config, invalid); err != nil {
+				return nil, err
+			}
+		case r == terminal.KeyArrowLeft:
+			d.move(0, -1)
+		case r == terminal.KeyArrowRight:
+			d.move(0, 1)
+		case r == terminal.KeyArrowUp:
+			d.move(0, -7)
+		case r == terminal.KeyArrowDown:
+			d.move(0, 7)
+		case r == terminal.SpecialKeyPageUp:
+			d.move(-1, 0)
+		case r == terminal.SpecialKeyPageDown:
+			d.move(1, 0)
+		case r == terminal.KeyDelete || r == terminal.KeyBackspace:
+			// remove the last character the user typed
+			if input := []rune(d.input); len(input) > 0 {
+				d.input = string(input[:len(input)-1])
+			}
+			d.follow()
+		case r == terminal.KeyDeleteWord || r == terminal.KeyDeleteLine:
+			d.input = ""
+		case string(r) == config.HelpInput && d.Help != "":
+			d.showingHelp = true
+		case unicode.IsPrint(r):
+			d.input += string(r)
+			d.follow()
+		}
+
+		err = d.render(config)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (d *Date) render(config *PromptConfig) error {
+	return d.Render(
+		DateQuestionTemplate,
+		DateTemplateData{
+			Date:     *d,
+			Input:    d.input,
+			Month:    d.selected.Format("January 2006"),
+			Weekdays: d.weekdays(),
+			Weeks:    d.weeks(),
+			ShowHelp: d.showingHelp,
+			Config:   config,
+		},
+	)
+}
+
+// move changes the selected date by the given number of months and days, staying in range.
+func (d *Date) move(months int, days int) {
+	// moving around replaces whatever the user typed
+	d.input = ""
+
+	selected := d.selected.AddDate(0, 0, days)
+	if months != 0 {
+		selected = addMonths(d.selected, months)
+	}
+	d.selected = d.clamp(selected)
+}
+
+// follow selects the date the user is typing as soon as it makes sense.
+func (d *Date) follow() {
+	if date, err := d.parse(d.input); err == nil && d.check(date) == nil {
+		d.selected = date
+	}
+}
+
+// answer returns the date the user typed, or the selected one if they didn't type anything.
+func (d *Date) answer() (interface{}, error) {
+	if d.input == "" {
+		return d.selected, nil
+	}
+
+	date, err := d.parse(d.input)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.check(date); err != nil {
+		return nil, err
+	}
+	return date, nil
+}
+
+// parse reads a date in the prompt's layout or relative to today.
+func (d *Date) parse(text string) (time.Time, error) {
+	if match := relativeDateRx.FindStringSubmatch(text); match != nil {
+		count, err := strconv.Atoi(match[1])
+		if err != nil {
+			return time.Time{}, err
+		}
+		switch match[2] {
+		case "d":
+			return today().AddDate(0, 0, count), nil
+		case "w":
+			return today().AddDate(0, 0, 7*count), nil
+		case "m":
+			return addMonths(today(), count), nil
+		default:
+			return addMonths(today(), 12*count), nil
+		}
+	}
+
+	date, err := time.ParseInLocation(d.layout(), text, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a date like %s", text, d.layout())
+	}
+	return date, nil
+}
+
+// check makes sure the date is within range.
+func (d *Date) check(date time.Time) error {
+	if !d.Min.IsZero() && date.Before(truncateDay(d.Min)) {
+		return fmt.Errorf("%s is before %s", date.Format(d.layout()), d.Min.Format(d.layout()))
+	}
+	if !d.Max.IsZero() && date.After(truncateDay(d.Max)) {
+		return fmt.Errorf("%s is after %s", date.Format(d.layout()), d.Max.Format(d.layout()))
+	}
+	return nil
+}
+
+// clamp returns the closest date to the given one that is within range.
+func (d *Date) clamp(date time.Time) time.Time {
+	if !d.Min.IsZero() && date.Before(truncateDay(d.Min)) {
+		return truncateDay(d.Min)
+	}
+	if !d.Max.IsZero() && date.After(truncateDay(d.Max)) {
+		return truncateDay(d.Max)
+	}
+	return date
+}
+
+// start returns the date to start from, which is the default or today.
+func (d *Date) start() (time.Time, error) {
+	if !d.Min.IsZero() && !d.Max.IsZero() && d.Min.After(d.Max) {
+		return time.Time{}, errors.New("the minimum date must be before the maximum")
+	}
+
+	dflt := d.prefilled().Default
+	if dflt.IsZero() {
+		return d.clamp(today()), nil
+	}
+	return d.clamp(truncateDay(dflt)), nil
+}
+
+func (d *Date) layout() string {
+	if d.Layout == "" {
+		return DefaultDateLayout
+	}
+	return d.Layout
+}
+
+// weekdays returns the names of the days of the week, starting with WeekStart.
+func (d *Date) weekdays() []string {
+	names := []string{}
+	for i := 0; i < 7; i++ {
+		names = append(names, time.Weekday((int(d.WeekStart) + i) % 7).String()[:2])
+	}
+	return names
+}
+
+// weeks lays out the month of the selected date as weeks of days.
+func (d *Date) weeks() [][]CalendarDay {
+	first := time.Date(d.selected.Year(), d.selected.Month(), 1, 0, 0, 0, 0, d.selected.Location())
+
+	// pad out the days before the first of the month
+	week := make([]CalendarDay, (int(first.Weekday())-int(d.WeekStart)+7)%7)
+	weeks := [][]CalendarDay{}
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		week = append(week, CalendarDay{
+			Day:      day.Day(),
+			Selected: day.Day() == d.selected.Day(),
+			Disabled: d.check(day) != nil,
+		})
+		if len(week) == 7 {
+			weeks = append(weeks, week)
+			week = []CalendarDay{}
+		}
+	}
+	// and the ones after the end of it
+	if len(week) > 0 {
+		weeks = append(weeks, append(week, make([]CalendarDay, 7-len(week))...))
+	}
+
+	return weeks
+}
+
+// DefaultAnswer returns the answer the user would get by just pressing enter.
+func (d *Date) DefaultAnswer() (interface{}, error) {
+	return d.start()
+}
+
+// ConvertAnswer turns a supplied answer into a date. Besides a time.Time, the answer
+// can be anything the user could type or a date in the RFC 3339 format.
+func (d *Date) ConvertAnswer(value interface{}) (interface{}, error) {
+	var date time.Time
+	switch val := value.(type) {
+	case time.Time:
+		date = truncateDay(val)
+	case string:
+		parsed, err := d.parse(val)
+		if err != nil {
+			// dates that were written down by WithRecord are in RFC 3339
+			rfc, rfcErr := time.Parse(time.RFC3339, val)
+			if rfcErr != nil {
+				return nil, err
+			}
+			parsed = truncateDay(rfc)
+		}
+		date = parsed
+	default:
+		return nil, fmt.Errorf("cannot use a %T as a date", value)
+	}
+
+	if err := d.check(date); err != nil {
+		return nil, err
+	}
+	return date, nil
+}
+
+// prefill makes an earlier answer the default when going back to the question, or
+// goes back to Default when there is none.
+func (d *Date) prefill(ans interface{}) {
+	d.previous = nil
+	if val, ok := ans.(time.Time); ok {
+		d.previous = &val
+	}
+}
+
+// prefilled is the prompt as the user sees it, with the earlier answer in place of
+// Default.
+func (d *Date) prefilled() Date {
+	prompt := *d
+	if d.previous != nil {
+		prompt.Default = *d.previous
+	}
+	return prompt
+}
+
+// formatAnswer returns the answer the way it is shown once the question is answered.
+func (d *Date) formatAnswer(val interface{}) string {
+	// transformers can turn the answer into something other than a time
+	if date, ok := val.(time.Time); ok {
+		return date.Format(d.layout())
+	}
+	return fmt.Sprint(val)
+}
+
+func (d *Date) Cleanup(config *PromptConfig, val interface{}) error {
+	return d.Render(
+		DateQuestionTemplate,
+		DateTemplateData{
+			Date:       *d,
+			Answer:     d.formatAnswer(val),
+			ShowAnswer: true,
+			Config:     config,
+		},
+	)
+}
+
+// today returns the start of the current day.
+func today() time.Time {
+	return truncateDay(time.Now())
+}
+
+// truncateDay returns the start of the day the time is in.
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// addMonths moves the date by the given number of months, staying in the last day
+// of the month if the new one is shorter.
+func addMonths(date time.Time, months int) time.Time {
+	first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location()).AddDate(0, months, 0)
+	last := first.AddDate(0, 1, -1).Day()
+
+	day := date.Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, date.Location())
+}
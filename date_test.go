@@ -0,0 +1,214 @@
+package survey
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	expect "github.com/Netflix/go-expect"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	// disable color output for all prompts to simplify testing
+	core.DisableColor = true
+}
+
+func TestDateRender(t *testing.T) {
+	prompt := Date{
+		Message: "When?",
+		Min:     time.Date(2026, time.October, 3, 0, 0, 0, 0, time.Local),
+	}
+	prompt.selected = time.Date(2026, time.October, 16, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		title    string
+		data     DateTemplateData
+		expected string
+	}{
+		{
+			"Test Date question output",
+			DateTemplateData{
+				Month:    "October 2026",
+				Weekdays: prompt.weekdays(),
+				Weeks:    prompt.weeks(),
+			},
+			fmt.Sprintf(
+				"%s When? [Use arrows to move, type a date]\n"+
+					"  October 2026\n"+
+					"   Su  Mo  Tu  We  Th  Fr  Sa \n"+
+					"                    1   2   3 \n"+
+					"    4   5   6   7   8   9  10 \n"+
+					"   11  12  13  14  15 [16] 17 \n"+
+					"   18  19  20  21  22  23  24 \n"+
+					"   25  26  27  28  29  30  31 \n",
+				defaultIcons().Question.Text,
+			),
+		},
+		{
+			"Test Date question output with input",
+			DateTemplateData{Input: "+3d", Month: "October 2026"},
+			fmt.Sprintf("%s When? [Use arrows to move, type a date] +3d\n  October 2026\n", defaultIcons().Question.Text),
+		},
+		{
+			"Test Date answer output",
+			DateTemplateData{Answer: "2026-10-16", ShowAnswer: true},
+			fmt.Sprintf("%s When? 2026-10-16\n", defaultIcons().Question.Text),
+		},
+	}
+
+	for _, test := range tests {
+		r, w, err := os.Pipe()
+		assert.Nil(t, err, test.title)
+
+		prompt.WithStdio(terminal.Stdio{Out: w})
+		test.data.Date = prompt
+
+		// set the runtime config
+		test.data.Config = defaultPromptConfig()
+
+		err = prompt.Render(
+			DateQuestionTemplate,
+			test.data,
+		)
+		assert.Nil(t, err, test.title)
+
+		w.Close()
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+
+		assert.Contains(t, buf.String(), test.expected, test.title)
+	}
+}
+
+func TestDateCleanup(t *testing.T) {
+	tests := []struct {
+		answer   interface{}
+		expected string
+	}{
+		{time.Date(2026, time.October, 16, 0, 0, 0, 0, time.Local), "When? 2026-10-16\n"},
+		// answers changed by a transformer are shown as they are
+		{"next friday", "When? next friday\n"},
+	}
+
+	for _, test := range tests {
+		r, w, err := os.Pipe()
+		assert.Nil(t, err)
+
+		prompt := Date{Message: "When?"}
+		prompt.WithStdio(terminal.Stdio{Out: w})
+		err = prompt.Cleanup(defaultPromptConfig(), test.answer)
+		assert.Nil(t, err)
+
+		w.Close()
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+
+		assert.Contains(t, buf.String(), test.expected)
+	}
+}
+
+func TestDatePrompt(t *testing.T) {
+	start := time.Date(2026, time.January, 31, 0, 0, 0, 0, time.Local)
+
+	tests := []PromptTest{
+		{
+			"Test Date prompt interaction with default",
+			&Date{Message: "When?", Default: start},
+			func(c *expect.Console) {
+				c.ExpectString("When?")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			start,
+		},
+		{
+			"Test Date prompt interaction with arrows",
+			&Date{Message: "When?", Default: start},
+			func(c *expect.Console) {
+				c.ExpectString("When?")
+				// a week forward and a day back
+				c.Send(string(terminal.KeyArrowDown))
+				c.Send(string(terminal.KeyArrowLeft))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			time.Date(2026, time.February, 6, 0, 0, 0, 0, time.Local),
+		},
+		{
+			"Test Date prompt page down stays in the month",
+			&Date{Message: "When?", Default: start},
+			func(c *expect.Console) {
+				c.ExpectString("When?")
+				c.Send("\x1b[6~")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			time.Date(2026, time.February, 28, 0, 0, 0, 0, time.Local),
+		},
+		{
+			"Test Date prompt arrows stay in range",
+			&Date{Message: "When?", Default: start, Min: start},
+			func(c *expect.Console) {
+				c.ExpectString("When?")
+				c.Send(string(terminal.KeyArrowUp))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			start,
+		},
+		{
+			"Test Date prompt interaction with typed date",
+			&Date{Message: "When?", Default: start},
+			func(c *expect.Console) {
+				c.ExpectString("When?")
+				c.SendLine("2026-10-16")
+				c.ExpectEOF()
+			},
+			time.Date(2026, time.October, 16, 0, 0, 0, 0, time.Local),
+		},
+		{
+			"Test Date prompt asks again when out of range",
+			&Date{Message: "When?", Default: start, Max: start},
+			func(c *expect.Console) {
+				c.ExpectString("When?")
+				c.SendLine("2026-02-01")
+				c.ExpectString("is after")
+				c.Send(string(terminal.KeyDeleteLine))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			start,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			RunPromptTest(t, test)
+		})
+	}
+}
+
+func TestDateConvertAnswer(t *testing.T) {
+	prompt := &Date{Layout: "02/01/2006"}
+
+	answer, err := prompt.ConvertAnswer("16/10/2026")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2026, time.October, 16, 0, 0, 0, 0, time.Local), answer)
+
+	answer, err = prompt.ConvertAnswer("+3d")
+	assert.Nil(t, err)
+	assert.Equal(t, today().AddDate(0, 0, 3), answer)
+
+	_, err = prompt.ConvertAnswer("tomorrow")
+	assert.NotNil(t, err)
+
+	prompt.Max = today()
+	_, err = prompt.ConvertAnswer("+1w")
+	assert.NotNil(t, err)
+}
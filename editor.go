@@ -33,6 +33,8 @@ type Editor struct {
 	HideDefault   bool
 	AppendDefault bool
 	FileName      string
+	// the answer given last time, when going back to the question
+	previous *string
 }
 
 // data available to the templates when processing
@@ -80,8 +82,8 @@ func (e *Editor) PromptAgain(config *PromptConfig, invalid interface{}, err erro
 
 func (e *Editor) Prompt(config *PromptConfig) (interface{}, error) {
 	initialValue := ""
-	if e.Default != "" && e.AppendDefault {
-		initialValue = e.Default
+	if dflt := e.prefilled().Default; dflt != "" && e.AppendDefault {
+		initialValue = dflt
 	}
 	return e.prompt(initialValue, config)
 }
@@ -91,7 +93,7 @@ func (e *Editor) prompt(initialValue string, config *PromptConfig) (interface{},
 	err := e.Render(
 		EditorQuestionTemplate,
 		EditorTemplateData{
-			Editor: *e,
+			Editor: e.prefilled(),
 			Config: config,
 		},
 	)
@@ -119,6 +121,9 @@ func (e *Editor) prompt(initialValue string, config *PromptConfig) (interface{},
 		if r == terminal.KeyInterrupt {
 			return "", terminal.InterruptErr
 		}
+		if r == terminal.SpecialKeyShiftTab {
+			return "", terminal.GoBackErr
+		}
 		if r == terminal.KeyEndTransmission {
 			break
 		}
@@ -126,7 +131,7 @@ func (e *Editor) prompt(initialValue string, config *PromptConfig) (interface{},
 			err = e.Render(
 				EditorQuestionTemplate,
 				EditorTemplateData{
-					Editor:   *e,
+					Editor:   e.prefilled(),
 					ShowHelp: true,
 					Config:   config,
 				},
@@ -185,6 +190,10 @@ func (e *Editor) prompt(initialValue string, config *PromptConfig) (interface{},
 	// open the editor
 	cmd := exec.Command(args[0], args[1:]...)
 	cmd.Stdin = stdio.In
+	// the editor needs the terminal itself, not the wrapper survey reads through
+	if r, ok := stdio.In.(*cancelableReader); ok {
+		cmd.Stdin = r.in
+	}
 	cmd.Stdout = stdio.Out
 	cmd.Stderr = stdio.Err
 	cursor.Show()
@@ -203,18 +212,52 @@ func (e *Editor) prompt(initialValue string, config *PromptConfig) (interface{},
 
 	// check length, return default value on empty
 	if len(text) == 0 && !e.AppendDefault {
-		return e.Default, nil
+		return e.prefilled().Default, nil
 	}
 
 	return text, nil
 }
 
+// DefaultAnswer returns the answer the user would get by saving the file without
+// making any changes.
+func (e *Editor) DefaultAnswer() (interface{}, error) {
+	dflt := e.prefilled().Default
+	if dflt == "" {
+		return nil, ErrNoDefault
+	}
+	return dflt, nil
+}
+
+// prefill makes an earlier answer the default when going back to the question, or
+// goes back to Default when there is none.
+func (e *Editor) prefill(ans interface{}) {
+	e.previous = nil
+	if val, ok := ans.(string); ok {
+		e.previous = &val
+	}
+}
+
+// prefilled is the prompt as the user sees it, with the earlier answer in place of
+// Default.
+func (e *Editor) prefilled() Editor {
+	prompt := *e
+	if e.previous != nil {
+		prompt.Default = *e.previous
+	}
+	return prompt
+}
+
+// formatAnswer returns the answer the way it is shown once the question is answered.
+func (e *Editor) formatAnswer(val interface{}) string {
+	return "<Received>"
+}
+
 func (e *Editor) Cleanup(config *PromptConfig, val interface{}) error {
 	return e.Render(
 		EditorQuestionTemplate,
 		EditorTemplateData{
 			Editor:     *e,
-			Answer:     "<Received>",
+			Answer:     e.formatAnswer(val),
 			ShowAnswer: true,
 			Config:     config,
 		},
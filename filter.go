@@ -1 +1,447 @@
 package survey
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/AlecAivazis/survey/v2/core"
+)
+
+// Matcher decides if an option matches what the user typed to filter the options. A
+// higher score puts the option further up the list, and positions holds the indices of
+// the runes in the option that matched so they can be highlighted. Typing more should
+// only ever narrow the matches, since the prompts only check the options that matched
+// before when the user keeps typing.
+type Matcher func(filter string, value string, index int) (score int, positions []int, ok bool)
+
+// the scores FuzzyMatch gives, following the ones fzf uses
+const (
+	scoreMatch        = 16
+	scoreGapStart     = -3
+	scoreGapExtension = -1
+	bonusBoundary     = scoreMatch / 2
+	bonusCamelCase    = bonusBoundary - 1
+	bonusConsecutive  = -(scoreGapStart + scoreGapExtension)
+	bonusFirstRune    = 2
+)
+
+/*
+FuzzyMatch is a Matcher that lets the user type a few runes of the option they want
+instead of part of it. An option matches if it holds all of the runes of the filter in
+order, ignoring case. Runes that are next to each other or start a word score higher,
+and gaps between them score lower, so the options that match the filter most closely
+come first.
+
+	survey.AskOne(prompt, &color, survey.WithMatcher(survey.FuzzyMatch))
+*/
+func FuzzyMatch(filter string, value string, index int) (int, []int, bool) {
+	if filter == "" {
+		return 0, nil, true
+	}
+	// most options don't match at all, so check that before doing any work
+	if !hasSubsequence(filter, value) {
+		return 0, nil, false
+	}
+
+	// a single rune scores best where it starts a word
+	if first, size := utf8.DecodeRuneInString(filter); size == len(filter) {
+		first = toLower(first)
+		best, at, j, prev := -1, -1, 0, rune(-1)
+		for _, r := range value {
+			if toLower(r) == first {
+				if bonus := runeBonus(prev, r); bonus > best {
+					best, at = bonus, j
+				}
+			}
+			prev = r
+			j++
+		}
+		return scoreMatch + best*bonusFirstRune, []int{at}, true
+	}
+
+	scratch := fuzzyScratchPool.Get().(*fuzzyScratch)
+	defer fuzzyScratchPool.Put(scratch)
+
+	pattern := scratch.pattern[:0]
+	for _, r := range filter {
+		pattern = append(pattern, toLower(r))
+	}
+	scratch.pattern = pattern
+
+	text, lower := scratch.text[:0], scratch.lower[:0]
+	for _, r := range value {
+		text = append(text, r)
+		lower = append(lower, toLower(r))
+	}
+	scratch.text, scratch.lower = text, lower
+
+	// score[i*n+j] is the best score with the first i+1 runes of the pattern matched and
+	// the last one of them at j, and from[i*n+j] is where the one before it matched
+	n := len(text)
+	if cells := len(pattern) * n; cap(scratch.score) < cells {
+		scratch.score = make([]int, cells)
+		scratch.from = make([]int, cells)
+	}
+	score, from := scratch.score[:len(pattern)*n], scratch.from[:len(pattern)*n]
+	const none = -1 << 30
+
+	for i, p := range pattern {
+		row := score[i*n : (i+1)*n]
+		var prevRow []int
+		if i > 0 {
+			prevRow = score[(i-1)*n : i*n]
+		}
+		// the best score to continue from with a gap, and where it matched
+		gap, gapFrom := none, -1
+		for j, c := range lower {
+			row[j], from[i*n+j] = none, -1
+			if i > 0 && j > 1 {
+				// a gap gets longer as we move along
+				if gap != none {
+					gap += scoreGapExtension
+				}
+				if prev := prevRow[j-2]; prev != none && prev+scoreGapStart > gap {
+					gap, gapFrom = prev+scoreGapStart, j-2
+				}
+			}
+			if c != p {
+				continue
+			}
+
+			bonus := boundaryBonus(text, j)
+			if i == 0 {
+				row[j] = scoreMatch + bonus*bonusFirstRune
+				continue
+			}
+			if j > 0 && prevRow[j-1] != none {
+				// runes next to each other score higher than ones with a gap between them
+				row[j], from[i*n+j] = prevRow[j-1]+scoreMatch+bonus+bonusConsecutive, j-1
+			}
+			if gap != none && gap+scoreMatch+bonus > row[j] {
+				row[j], from[i*n+j] = gap+scoreMatch+bonus, gapFrom
+			}
+		}
+	}
+
+	// find where the best match ends
+	last := len(pattern) - 1
+	best, end := none, -1
+	for j, s := range score[last*n:] {
+		if s > best {
+			best, end = s, j
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	// and follow it back to where it started
+	positions := make([]int, len(pattern))
+	for i := last; i >= 0; i-- {
+		positions[i] = end
+		end = from[i*n+end]
+	}
+	return best, positions, true
+}
+
+// fuzzyScratch holds the buffers FuzzyMatch works in, which are reused between calls
+// since it is run on every option for every key the user presses.
+type fuzzyScratch struct {
+	pattern []rune
+	text    []rune
+	lower   []rune
+	score   []int
+	from    []int
+}
+
+var fuzzyScratchPool = sync.Pool{
+	New: func() interface{} { return &fuzzyScratch{} },
+}
+
+// hasSubsequence returns true if the value holds all of the runes of the filter in
+// order, ignoring case.
+func hasSubsequence(filter string, value string) bool {
+	// compare bytes for as long as both are ASCII, which most options are
+	for len(filter) > 0 && len(value) > 0 && filter[0] < utf8.RuneSelf && value[0] < utf8.RuneSelf {
+		if toLower(rune(value[0])) == toLower(rune(filter[0])) {
+			filter = filter[1:]
+		}
+		value = value[1:]
+	}
+
+	for _, r := range value {
+		if filter == "" {
+			break
+		}
+		if next, size := utf8.DecodeRuneInString(filter); toLower(r) == toLower(next) {
+			filter = filter[size:]
+		}
+	}
+	return filter == ""
+}
+
+// toLower lowers the case of a rune, skipping the unicode tables for ASCII.
+func toLower(r rune) rune {
+	if r < utf8.RuneSelf {
+		if 'A' <= r && r <= 'Z' {
+			r += 'a' - 'A'
+		}
+		return r
+	}
+	return unicode.ToLower(r)
+}
+
+// boundaryBonus returns the bonus for matching the rune at the given index, which is
+// higher if the rune starts a word.
+func boundaryBonus(text []rune, index int) int {
+	if index == 0 {
+		return runeBonus(-1, text[index])
+	}
+	return runeBonus(text[index-1], text[index])
+}
+
+// runeBonus returns the bonus for matching a rune that comes after the given one, or
+// after -1 if it is the first rune.
+func runeBonus(prev rune, curr rune) int {
+	switch {
+	case prev == -1:
+		return bonusBoundary
+	case !unicode.IsLetter(prev) && !unicode.IsDigit(prev) && (unicode.IsLetter(curr) || unicode.IsDigit(curr)):
+		return bonusBoundary
+	case unicode.IsLower(prev) && unicode.IsUpper(curr):
+		return bonusCamelCase
+	}
+	return 0
+}
+
+// filterMatcher returns the Matcher a prompt filters its options with: its own Filter or
+// Matcher if it has one, and otherwise the ones in the config. It also says if the
+// matcher only narrows the options as the filter grows.
+func filterMatcher(filter func(string, string, int) bool, matcher Matcher, config *PromptConfig) (Matcher, bool) {
+	switch {
+	case filter != nil:
+		return filterFuncMatcher(filter)
+	case matcher != nil:
+		return matcher, true
+	case config.Matcher != nil:
+		return config.Matcher, true
+	case config.Filter != nil:
+		return filterFuncMatcher(config.Filter)
+	}
+	return containsMatch, true
+}
+
+// filterFuncMatcher turns a boolean filter into a Matcher. The default filter narrows the
+// options and knows where it matched, which other filters were never asked to promise.
+func filterFuncMatcher(filter func(string, string, int) bool) (Matcher, bool) {
+	if reflect.ValueOf(filter).Pointer() == reflect.ValueOf(containsFilter).Pointer() {
+		return containsMatch, true
+	}
+	return boolMatcher(filter), false
+}
+
+// containsFilter is the default filter, which keeps the options that contain what the
+// user typed, ignoring case.
+func containsFilter(filter string, value string, index int) bool {
+	filter = strings.ToLower(filter)
+
+	// include this option if it matches
+	return strings.Contains(strings.ToLower(value), filter)
+}
+
+// containsMatch is the Matcher for containsFilter, which also returns where the filter
+// is in the option so it can be highlighted.
+func containsMatch(filter string, value string, index int) (int, []int, bool) {
+	if filter == "" {
+		return 0, nil, true
+	}
+	lower := strings.ToLower(value)
+	at := strings.Index(lower, strings.ToLower(filter))
+	if at < 0 {
+		return 0, nil, false
+	}
+
+	// lowering the case keeps the runes where they were, so count them up to the match
+	start := utf8.RuneCountInString(lower[:at])
+	positions := make([]int, utf8.RuneCountInString(filter))
+	for i := range positions {
+		positions[i] = start + i
+	}
+	return 0, positions, true
+}
+
+// boolMatcher turns a filter that only says if an option matches into a Matcher that
+// keeps the options in order.
+func boolMatcher(filter func(string, string, int) bool) Matcher {
+	return func(value string, option string, index int) (int, []int, bool) {
+		return 0, nil, filter(value, option, index)
+	}
+}
+
+// filterResult holds the options that matched a filter.
+type filterResult struct {
+	filter string
+	// the options that matched in the order they were given, to narrow down from
+	matched []core.OptionAnswer
+	// the options that matched with the best matches first
+	answers []core.OptionAnswer
+}
+
+// optionFilter filters the options of a prompt, holding on to the results for the
+// filter as it was typed. Matching the same filter again is free, typing more only
+// checks the options that matched before, and deleting goes back to an earlier result.
+type optionFilter struct {
+	all []core.OptionAnswer
+	// the run of options under the same header that each option is in, if they have any
+	groups  []int
+	results []filterResult
+}
+
+// reset makes the filter start over with the given options, which are ranked within the
+// groups they are in if they have any.
+func (f *optionFilter) reset(options []string, groups []int) {
+	f.all = core.OptionAnswerList(options)
+	f.groups = groups
+	f.results = nil
+}
+
+// apply returns the options that match the filter, best matches first. The result
+// belongs to the filter, so it shouldn't be changed.
+func (f *optionFilter) apply(match Matcher, narrows bool, filter string) []core.OptionAnswer {
+	// drop the results that don't lead up to this filter
+	for n := len(f.results); n > 0; n = len(f.results) {
+		if last := f.results[n-1].filter; last == filter || (narrows && strings.HasPrefix(filter, last)) {
+			break
+		}
+		f.results = f.results[:n-1]
+	}
+	if filter == "" {
+		return f.all
+	}
+
+	// only the options that matched the last filter can match this one
+	candidates := f.all
+	if n := len(f.results); n > 0 {
+		if f.results[n-1].filter == filter {
+			return f.results[n-1].answers
+		}
+		candidates = f.results[n-1].matched
+	}
+
+	// make room for every candidate up front, growing the lists as we go takes longer
+	result := filterResult{filter: filter, matched: make([]core.OptionAnswer, 0, len(candidates))}
+	scores := make([]int, 0, len(candidates))
+	for _, opt := range candidates {
+		// if the filter says to include the option
+		if score, _, ok := match(filter, opt.Value, opt.Index); ok {
+			result.matched = append(result.matched, opt)
+			scores = append(scores, score)
+		}
+	}
+
+	// put the best matches first, keeping the groups in order so their headers are only
+	// shown once
+	order := rank(scores)
+	if f.groups != nil {
+		sort.SliceStable(order, func(a, b int) bool {
+			return f.groups[result.matched[order[a]].Index] < f.groups[result.matched[order[b]].Index]
+		})
+	}
+	result.answers = make([]core.OptionAnswer, len(order))
+	for i, idx := range order {
+		result.answers[i] = result.matched[idx]
+	}
+
+	f.results = append(f.results, result)
+	return result.answers
+}
+
+// rank returns the indices of the scores from highest to lowest, keeping the ones that
+// are the same in order.
+func rank(scores []int) []int {
+	order := make([]int, len(scores))
+	if len(scores) == 0 {
+		return order
+	}
+
+	low, high := scores[0], scores[0]
+	for _, score := range scores {
+		if score < low {
+			low = score
+		}
+		if score > high {
+			high = score
+		}
+	}
+
+	// scores from a matcher we don't know might be spread too far to count them
+	if high-low > 4*len(scores)+1024 {
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return scores[order[a]] > scores[order[b]]
+		})
+		return order
+	}
+
+	// count how many options have each score, and turn that into where they start
+	starts := make([]int, high-low+2)
+	for _, score := range scores {
+		starts[high-score+1]++
+	}
+	for i := 1; i < len(starts); i++ {
+		starts[i] += starts[i-1]
+	}
+	for i, score := range scores {
+		order[starts[high-score]] = i
+		starts[high-score]++
+	}
+	return order
+}
+
+// segments splits a page of the options that matched the last filter into the pieces
+// that matched and the ones in between, keyed by the option's index. Only the options
+// on the page are matched again to find out where they matched, which is a lot cheaper
+// than holding on to that for every option.
+func (f *optionFilter) segments(match Matcher, page []core.OptionAnswer) map[int][]MatchSegment {
+	segments := map[int][]MatchSegment{}
+	if len(f.results) == 0 {
+		return segments
+	}
+	filter := f.results[len(f.results)-1].filter
+
+	for _, opt := range page {
+		_, positions, ok := match(filter, opt.Value, opt.Index)
+		if !ok || len(positions) == 0 {
+			continue
+		}
+
+		matched := map[int]bool{}
+		for _, pos := range positions {
+			matched[pos] = true
+		}
+
+		pieces := []MatchSegment{}
+		for j, r := range []rune(opt.Value) {
+			if n := len(pieces); n > 0 && pieces[n-1].Matched == matched[j] {
+				pieces[n-1].Text += string(r)
+			} else {
+				pieces = append(pieces, MatchSegment{Text: string(r), Matched: matched[j]})
+			}
+		}
+		segments[opt.Index] = pieces
+	}
+	return segments
+}
+
+// MatchSegment is a piece of an option, which the templates highlight if it matched the
+// filter.
+type MatchSegment struct {
+	Text    string
+	Matched bool
+}
@@ -0,0 +1,215 @@
+package survey
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		filter    string
+		value     string
+		ok        bool
+		positions []int
+	}{
+		{"", "anything", true, nil},
+		{"tue", "Tuesday", true, []int{0, 1, 2}},
+		{"TUE", "tuesday", true, []int{0, 1, 2}},
+		{"sdy", "Tuesday", true, []int{3, 4, 6}},
+		{"ew", "us-east-1 eu-west-1", true, []int{10, 13}},
+		{"bc", "fooBarCar", true, []int{3, 6}},
+		{"yad", "Tuesday", false, nil},
+		{"longer than the value", "short", false, nil},
+	}
+
+	for _, test := range tests {
+		_, positions, ok := FuzzyMatch(test.filter, test.value, 0)
+		assert.Equal(t, test.ok, ok, "%q in %q", test.filter, test.value)
+		assert.Equal(t, test.positions, positions, "%q in %q", test.filter, test.value)
+	}
+}
+
+func TestFuzzyMatch_ranking(t *testing.T) {
+	score := func(filter string, value string) int {
+		score, _, ok := FuzzyMatch(filter, value, 0)
+		assert.True(t, ok, "%q in %q", filter, value)
+		return score
+	}
+
+	// runes that are next to each other beat ones that are spread out
+	assert.True(t, score("sun", "Sunday") > score("sun", "Saturn"))
+	// as do runes that start a word
+	assert.True(t, score("ew", "eu-west-1") > score("ew", "hewn"))
+	// and shorter gaps beat longer ones
+	assert.True(t, score("ad", "abd") > score("ad", "abbbd"))
+}
+
+func TestOptionFilter(t *testing.T) {
+	days := []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+	f := optionFilter{}
+	f.reset(days, nil)
+
+	assert.Equal(t, core.OptionAnswerList(days), f.apply(FuzzyMatch, true, ""))
+	assert.Equal(t, []core.OptionAnswer{
+		{Value: "Tuesday", Index: 2},
+		{Value: "Thursday", Index: 4},
+		{Value: "Saturday", Index: 6},
+	}, f.apply(FuzzyMatch, true, "tu"))
+
+	// filters that only say if an option matches keep the order of the options
+	filter := func(filter string, value string, index int) bool {
+		return strings.Contains(strings.ToLower(value), filter)
+	}
+	assert.Equal(t, []core.OptionAnswer{
+		{Value: "Thursday", Index: 4},
+		{Value: "Saturday", Index: 6},
+	}, f.apply(boolMatcher(filter), false, "ur"))
+}
+
+func TestOptionFilter_narrows(t *testing.T) {
+	f := optionFilter{}
+	f.reset([]string{"api", "web", "worker", "database"}, nil)
+
+	// keep track of the options the matcher looks at
+	checked := []string{}
+	match := func(filter string, value string, index int) (int, []int, bool) {
+		checked = append(checked, value)
+		return FuzzyMatch(filter, value, index)
+	}
+
+	f.apply(match, true, "w")
+	assert.Equal(t, []string{"api", "web", "worker", "database"}, checked)
+
+	// typing more only looks at the options that matched before
+	checked = []string{}
+	assert.Equal(t, []core.OptionAnswer{{Value: "worker", Index: 2}}, f.apply(match, true, "wr"))
+	assert.Equal(t, []string{"web", "worker"}, checked)
+
+	// and deleting goes back to what matched before without looking again
+	checked = []string{}
+	assert.Equal(t, []core.OptionAnswer{{Value: "web", Index: 1}, {Value: "worker", Index: 2}}, f.apply(match, true, "w"))
+	assert.Empty(t, checked)
+
+	// filters that don't promise to narrow look at everything
+	f.apply(match, false, "wr")
+	assert.Equal(t, []string{"api", "web", "worker", "database"}, checked)
+
+	// but only once
+	checked = []string{}
+	f.apply(match, false, "wr")
+	assert.Empty(t, checked)
+}
+
+func TestOptionFilter_segments(t *testing.T) {
+	f := optionFilter{}
+	f.reset([]string{"Monday", "Tuesday", "Wednesday"}, nil)
+	options := f.apply(FuzzyMatch, true, "tua")
+
+	assert.Equal(t,
+		map[int][]MatchSegment{
+			1: {{Text: "Tu", Matched: true}, {Text: "esd"}, {Text: "a", Matched: true}, {Text: "y"}},
+		},
+		f.segments(FuzzyMatch, options),
+	)
+}
+
+func TestFilterMatcher(t *testing.T) {
+	byLength := func(filter string, value string, index int) bool {
+		return len(value) > len(filter)
+	}
+	exact := func(filter string, value string, index int) (int, []int, bool) {
+		return 0, nil, filter == value
+	}
+
+	// the options are filtered by what they contain by default, which narrows them down
+	// and says where the filter is so it can be highlighted
+	match, narrows := filterMatcher(nil, nil, defaultPromptConfig())
+	_, positions, ok := match("RE", "Dark red", 0)
+	assert.True(t, ok)
+	assert.Equal(t, []int{5, 6}, positions)
+	_, positions, ok = match("rö", "Grün rötlich", 0)
+	assert.True(t, ok)
+	assert.Equal(t, []int{5, 6}, positions)
+	_, _, ok = match("rd", "red", 0)
+	assert.False(t, ok)
+	assert.True(t, narrows)
+
+	// and fuzzy matching can be asked for
+	options := defaultAskOptions()
+	assert.Nil(t, WithMatcher(FuzzyMatch)(options))
+	match, narrows = filterMatcher(nil, nil, &options.PromptConfig)
+	_, _, ok = match("rd", "red", 0)
+	assert.True(t, ok)
+	assert.True(t, narrows)
+
+	// a matcher on the prompt comes before the config
+	match, _ = filterMatcher(nil, exact, defaultPromptConfig())
+	_, _, ok = match("rd", "red", 0)
+	assert.False(t, ok)
+
+	// and a filter on the prompt comes before both
+	match, narrows = filterMatcher(byLength, exact, defaultPromptConfig())
+	_, _, ok = match("rd", "red", 0)
+	assert.True(t, ok)
+	assert.False(t, narrows)
+
+	// a filter given to ask replaces the matcher
+	options = defaultAskOptions()
+	assert.Nil(t, WithMatcher(FuzzyMatch)(options))
+	assert.Nil(t, WithFilter(byLength)(options))
+	match, _ = filterMatcher(nil, nil, &options.PromptConfig)
+	_, _, ok = match("rd", "green", 0)
+	assert.True(t, ok)
+}
+
+// manyOptions returns a list of options as long as the package and host lists people pick from.
+func manyOptions(count int) []string {
+	words := []string{"api", "web", "worker", "database", "cache", "queue", "search", "metrics"}
+	options := make([]string, count)
+	for i := range options {
+		options[i] = fmt.Sprintf("%s-%s-%d.internal", words[i%len(words)], words[(i/len(words))%len(words)], i)
+	}
+	return options
+}
+
+func BenchmarkFuzzyMatch(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		FuzzyMatch("wrkdb", "worker-database-4211.internal", 0)
+		FuzzyMatch("wrkdb", "search-metrics-4212.internal", 0)
+	}
+}
+
+// BenchmarkSelectKeypress measures how long the prompt takes to handle the keys and draw
+// itself again while the user types a filter over 100k options and deletes it again. Every
+// op is 12 keys.
+func BenchmarkSelectKeypress(b *testing.B) {
+	out, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer out.Close()
+
+	options := manyOptions(100000)
+	keys := []rune("wrkdb4")
+	for range keys {
+		keys = append(keys, terminal.KeyBackspace)
+	}
+	config := defaultPromptConfig()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		prompt := &Select{Message: "Pick a host:", Options: options}
+		prompt.WithStdio(terminal.Stdio{In: os.Stdin, Out: out, Err: out})
+		for _, key := range keys {
+			prompt.OnChange(key, config)
+		}
+	}
+}
@@ -1,5 +1,12 @@
 package survey
 
+import (
+	"errors"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
 /*
 Input is a regular text input that prints each character the user types on the screen
 and accepts the input with the enter key. Response type is a string.
@@ -7,21 +14,40 @@ and accepts the input with the enter key. Response type is a string.
 	name := ""
 	prompt := &survey.Input{ Message: "What is your name?" }
 	survey.AskOne(prompt, &name)
+
+If Suggest is set, pressing tab completes what the user typed when there is a single
+suggestion for it, and otherwise lists the suggestions to pick from.
+
+	prompt := &survey.Input{
+		Message: "Which file?",
+		Suggest: func(toComplete string) []string {
+			files, _ := filepath.Glob(toComplete + "*")
+			return files
+		},
+	}
 */
 type Input struct {
 	Renderer
-	Message string
-	Default string
-	Help    string
+	Message       string
+	Default       string
+	Help          string
+	Suggest       func(toComplete string) []string
+	options       []core.OptionAnswer
+	selectedIndex int
+	showingHelp   bool
+	// the answer given last time, when going back to the question
+	previous *string
 }
 
 // data available to the templates when processing
 type InputTemplateData struct {
 	Input
-	Answer     string
-	ShowAnswer bool
-	ShowHelp   bool
-	Config     *PromptConfig
+	Answer        string
+	ShowAnswer    bool
+	ShowHelp      bool
+	PageEntries   []core.OptionAnswer
+	SelectedIndex int
+	Config        *PromptConfig
 }
 
 // Templates with Color formatting. See Documentation: https://github.com/mgutz/ansi#style-format
@@ -31,20 +57,28 @@ var InputQuestionTemplate = `
 {{- color "default+hb"}}{{ .Message }} {{color "reset"}}
 {{- if .ShowAnswer}}
   {{- color "cyan"}}{{.Answer}}{{color "reset"}}{{"\n"}}
+{{- else if .PageEntries}}
+  {{- .Answer}} {{color "cyan"}}[Use arrows to move, enter to select, esc to go back]{{color "reset"}}{{"\n"}}
+  {{- range $ix, $choice := .PageEntries}}
+    {{- if eq $ix $.SelectedIndex }}{{color $.Config.Icons.SelectFocus.Format }}{{ $.Config.Icons.SelectFocus.Text }} {{else}}{{color "default"}}  {{end}}
+    {{- $choice.Value}}
+    {{- color "reset"}}{{"\n"}}
+  {{- end}}
 {{- else }}
   {{- if and .Help (not .ShowHelp)}}{{color "cyan"}}[{{ print .Config.HelpInput }} for help]{{color "reset"}} {{end}}
+  {{- if .Suggest}}{{color "cyan"}}[tab for suggestions]{{color "reset"}} {{end}}
   {{- if .Default}}{{color "white"}}({{.Default}}) {{color "reset"}}{{end}}
 {{- end}}`
 
+// errSuggest stops reading a line so the user can be offered suggestions
+var errSuggest = errors.New("suggest")
+
 func (i *Input) Prompt(config *PromptConfig) (interface{}, error) {
+	i.options = nil
+	i.showingHelp = false
+
 	// render the template
-	err := i.Render(
-		InputQuestionTemplate,
-		InputTemplateData{
-			Input:  *i,
-			Config: config,
-		},
-	)
+	err := i.render(config)
 	if err != nil {
 		return "", err
 	}
@@ -59,7 +93,15 @@ func (i *Input) Prompt(config *PromptConfig) (interface{}, error) {
 	line := []rune{}
 	// get the next line
 	for {
-		line, err = rr.ReadLine(0)
+		line, err = rr.ReadLineWithDefault(0, line, i.onRune)
+		// if the user asked for suggestions, let them pick one and keep typing
+		if err == errSuggest {
+			line, err = i.suggest(rr, line, config)
+			if err != nil {
+				return "", err
+			}
+			continue
+		}
 		if err != nil {
 			return string(line), err
 		}
@@ -67,14 +109,9 @@ func (i *Input) Prompt(config *PromptConfig) (interface{}, error) {
 		cursor.PreviousLine(1)
 
 		if string(line) == config.HelpInput && i.Help != "" {
-			err = i.Render(
-				InputQuestionTemplate,
-				InputTemplateData{
-					Input:    *i,
-					ShowHelp: true,
-					Config:   config,
-				},
-			)
+			i.showingHelp = true
+			line = []rune{}
+			err = i.render(config)
 			if err != nil {
 				return "", err
 			}
@@ -86,13 +123,114 @@ func (i *Input) Prompt(config *PromptConfig) (interface{}, error) {
 	// if the line is empty
 	if line == nil || len(line) == 0 {
 		// use the default value
-		return i.Default, err
+		return i.prefilled().Default, err
 	}
 
 	// we're done
 	return string(line), err
 }
 
+func (i *Input) render(config *PromptConfig) error {
+	data := InputTemplateData{
+		Input:    i.prefilled(),
+		ShowHelp: i.showingHelp,
+		Config:   config,
+	}
+	if len(i.options) > 0 {
+		data.Answer = i.options[i.selectedIndex].Value
+		data.PageEntries, data.SelectedIndex = paginate(config.PageSize, i.options, i.selectedIndex)
+	}
+
+	return i.Render(InputQuestionTemplate, data)
+}
+
+// onRune stops reading the line when the user asks for suggestions.
+func (i *Input) onRune(key rune, line []rune) ([]rune, bool, error) {
+	if key == terminal.KeyTab && i.Suggest != nil {
+		return line, true, errSuggest
+	}
+	return line, false, nil
+}
+
+// suggest completes the line if there is a single suggestion for it, or lets the user
+// pick one from a list otherwise. It returns the line to keep typing from.
+func (i *Input) suggest(rr *terminal.RuneReader, line []rune, config *PromptConfig) ([]rune, error) {
+	suggestions := i.Suggest(string(line))
+	if len(suggestions) == 1 {
+		line = []rune(suggestions[0])
+	}
+	if len(suggestions) < 2 {
+		// the line will be printed again so we have to clear the one the user typed
+		return line, i.render(config)
+	}
+
+	i.options = core.OptionAnswerList(suggestions)
+	i.selectedIndex = 0
+
+	cursor := i.NewCursor()
+	cursor.Hide()       // hide the cursor
+	defer cursor.Show() // show the cursor when we're done
+
+	for {
+		err := i.render(config)
+		if err != nil {
+			return nil, err
+		}
+
+		r, _, err := rr.ReadRune()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case r == terminal.KeyInterrupt:
+			return nil, terminal.InterruptErr
+		case r == terminal.KeyEnter || r == '\n':
+			return []rune(i.options[i.selectedIndex].Value), i.clearOptions(config)
+		case r == terminal.KeyEscape:
+			return line, i.clearOptions(config)
+		case r == terminal.KeyArrowUp:
+			i.selectedIndex = (i.selectedIndex - 1 + len(i.options)) % len(i.options)
+		case r == terminal.KeyArrowDown || r == terminal.KeyTab:
+			i.selectedIndex = (i.selectedIndex + 1) % len(i.options)
+		}
+	}
+}
+
+// clearOptions hides the list of suggestions.
+func (i *Input) clearOptions(config *PromptConfig) error {
+	i.options = nil
+	return i.render(config)
+}
+
+// DefaultAnswer returns the answer the user would get by just pressing enter.
+func (i *Input) DefaultAnswer() (interface{}, error) {
+	dflt := i.prefilled().Default
+	if dflt == "" {
+		return nil, ErrNoDefault
+	}
+	return dflt, nil
+}
+
+// prefill makes an earlier answer the default when going back to the question, or
+// goes back to Default when there is none.
+func (i *Input) prefill(ans interface{}) {
+	i.previous = nil
+	if val, ok := ans.(string); ok {
+		i.previous = &val
+	}
+}
+
+// prefilled is the prompt as the user sees it, with the earlier answer in place of
+// Default.
+func (i *Input) prefilled() Input {
+	prompt := *i
+	if i.previous != nil {
+		prompt.Default = *i.previous
+	}
+	return prompt
+}
+
 func (i *Input) Cleanup(config *PromptConfig, val interface{}) error {
 	return i.Render(
 		InputQuestionTemplate,
@@ -68,6 +68,25 @@ func TestInputRender(t *testing.T) {
 			InputTemplateData{ShowHelp: true},
 			fmt.Sprintf("%s This is helpful\n%s What is your favorite month: (April) ", defaultIcons().Help.Text, defaultIcons().Question.Text),
 		},
+		{
+			"Test Input question output with suggestions",
+			Input{Message: "Which file?", Suggest: func(string) []string { return nil }},
+			InputTemplateData{},
+			fmt.Sprintf("%s Which file? [tab for suggestions] ", defaultIcons().Question.Text),
+		},
+		{
+			"Test Input question output picking a suggestion",
+			Input{Message: "Which file?"},
+			InputTemplateData{
+				Answer:        "go.sum",
+				PageEntries:   core.OptionAnswerList([]string{"go.mod", "go.sum"}),
+				SelectedIndex: 1,
+			},
+			fmt.Sprintf(
+				"%s Which file? go.sum [Use arrows to move, enter to select, esc to go back]\n  go.mod\n%s go.sum\n",
+				defaultIcons().Question.Text, defaultIcons().SelectFocus.Text,
+			),
+		},
 	}
 
 	for _, test := range tests {
@@ -165,6 +184,65 @@ func TestInputPrompt(t *testing.T) {
 			},
 			"R",
 		},
+		{
+			"Test Input prompt completes a single suggestion",
+			&Input{
+				Message: "Which file?",
+				Suggest: func(toComplete string) []string {
+					return []string{toComplete + "ain.go"}
+				},
+			},
+			func(c *expect.Console) {
+				c.ExpectString("Which file?")
+				c.Send("m")
+				c.Send(string(terminal.KeyTab))
+				c.ExpectString("main.go")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			"main.go",
+		},
+		{
+			"Test Input prompt picks from suggestions",
+			&Input{
+				Message: "Which file?",
+				Suggest: func(toComplete string) []string {
+					return []string{toComplete + ".mod", toComplete + ".sum"}
+				},
+			},
+			func(c *expect.Console) {
+				c.ExpectString("Which file?")
+				c.Send("go")
+				c.Send(string(terminal.KeyTab))
+				c.ExpectString("go.mod")
+				c.Send(string(terminal.KeyArrowDown))
+				c.SendLine("")
+				c.ExpectString("go.sum")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			"go.sum",
+		},
+		{
+			"Test Input prompt goes back from suggestions",
+			&Input{
+				Message: "Which file?",
+				Suggest: func(toComplete string) []string {
+					return []string{toComplete + ".mod", toComplete + ".sum"}
+				},
+			},
+			func(c *expect.Console) {
+				c.ExpectString("Which file?")
+				c.Send("go")
+				c.Send(string(terminal.KeyTab))
+				c.ExpectString("go.mod")
+				c.Send(string(terminal.KeyEscape))
+				c.ExpectString("[tab for suggestions]")
+				c.SendLine("land")
+				c.ExpectEOF()
+			},
+			"goland",
+		},
 	}
 
 	for _, test := range tests {
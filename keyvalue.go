@@ -0,0 +1,407 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+/*
+KeyValue is a prompt for a list of key=value pairs, like labels or environment variables.
+Each pair is typed on a row of its own and enter adds it to the list. The arrow keys
+go back to edit a row, which is removed by deleting it or clearing it out, and enter
+on the empty row at the bottom submits the list. Response type is a map[string]string,
+which can be written to a map of any type with string keys.
+
+	labels := map[string]string{}
+	prompt := &survey.KeyValue{
+		Message: "Which labels should the service have?",
+	}
+	survey.AskOne(prompt, &labels)
+*/
+type KeyValue struct {
+	Renderer
+	Message string
+	Default map[string]string
+	Help    string
+	// ValidateKey and ValidateValue are called with the key and value of every row
+	ValidateKey   Validator
+	ValidateValue Validator
+	rows          []keyValueRow
+	selectedIndex int
+	input         []rune
+	showingHelp   bool
+	// the answer given last time, when going back to the question
+	previous map[string]string
+}
+
+// keyValueRow is a pair that has been added to the list.
+type keyValueRow struct {
+	key   string
+	value string
+}
+
+func (row keyValueRow) String() string {
+	return row.key + "=" + row.value
+}
+
+// KeyValueTemplateData is the data available to the templates when processing
+type KeyValueTemplateData struct {
+	KeyValue
+	Rows          []string
+	SelectedIndex int
+	Input         string
+	Answer        string
+	ShowAnswer    bool
+	ShowHelp      bool
+	Config        *PromptConfig
+}
+
+var KeyValueQuestionTemplate = `
+{{- if .ShowHelp }}{{- color .Config.Icons.Help.Format }}{{ .Config.Icons.Help.Text }} {{ .Help }}{{color "reset"}}{{"\n"}}{{end}}
+{{- color .Config.Icons.Question.Format }}{{ .Config.Icons.Question.Text }} {{color "reset"}}
+{{- color "default+hb"}}{{ .Message }}{{color "reset"}}
+{{- if .ShowAnswer}}{{color "cyan"}} {{.Answer}}{{color "reset"}}{{"\n"}}
+{{- else}}
+  {{- "  "}}{{- color "cyan"}}
+  {{- if .Rows}}[Use arrows to edit, delete to remove{{else}}[Type key=value and enter to add{{end}}, enter on an empty row to submit
+  {{- if and .Help (not .ShowHelp)}}, {{ .Config.HelpInput }} for more help{{end}}]{{color "reset"}}
+  {{- range $ix, $row := .Rows}}
+    {{- "\n"}}
+    {{- if eq $ix $.SelectedIndex }}{{color $.Config.Icons.SelectFocus.Format }}{{ $.Config.Icons.SelectFocus.Text }} {{color "reset"}}{{color "cyan"}}{{ $.Input }}
+    {{- else}}{{color "default"}}  {{ $row }}{{end}}
+    {{- color "reset"}}
+  {{- end}}
+  {{- "\n"}}
+  {{- if eq .SelectedIndex (len .Rows) }}{{color .Config.Icons.SelectFocus.Format }}{{ .Config.Icons.SelectFocus.Text }} {{color "reset"}}{{ .Input }}{{end}}
+{{- end}}`
+
+func (k *KeyValue) Prompt(config *PromptConfig) (interface{}, error) {
+	// start off with the default pairs
+	return k.prompt(k.defaultRows(), config)
+}
+
+// PromptAgain asks for the pairs again, starting from the ones that didn't pass
+// validation so the user can change them.
+func (k *KeyValue) PromptAgain(config *PromptConfig, invalid interface{}, err error) (interface{}, error) {
+	return k.prompt(k.rows, config)
+}
+
+func (k *KeyValue) prompt(rows []keyValueRow, config *PromptConfig) (interface{}, error) {
+	k.rows = rows
+	k.selectedIndex = len(k.rows)
+	k.input = []rune{}
+	k.showingHelp = false
+
+	cursor := k.NewCursor()
+	defer cursor.Show() // show the cursor when we're done
+
+	// ask the question
+	err := k.render(config)
+	if err != nil {
+		return nil, err
+	}
+
+	rr := k.NewRuneReader()
+	rr.SetTermMode()
+	defer rr.RestoreTermMode()
+
+	// start waiting for input
+	for {
+		r, _, err := rr.ReadRune()
+		if err != nil {
+			return nil, err
+		}
+
+		// what went wrong with the row the user tried to leave
+		var invalid error
+
+		switch {
+		case r == terminal.KeyInterrupt:
+			return nil, terminal.InterruptErr
+		case r == terminal.SpecialKeyShiftTab:
+			return nil, terminal.GoBackErr
+		case r == terminal.KeyEnter || r == '\n' || r == terminal.KeyEndTransmission:
+			// enter on the empty row at the bottom submits the list
+			if k.selectedIndex == len(k.rows) && len(k.input) == 0 {
+				return k.answer(), nil
+			}
+			// otherwise add the row and start on a new one
+			if invalid = k.commit(); invalid == nil {
+				k.selectedIndex = len(k.rows)
+				k.input = []rune{}
+			}
+		case r == terminal.KeyArrowUp:
+			invalid = k.move(-1)
+		case r == terminal.KeyArrowDown:
+			invalid = k.move(1)
+		case r == terminal.SpecialKeyDelete:
+			k.remove()
+		case r == terminal.KeyDelete || r == terminal.KeyBackspace:
+			if len(k.input) > 0 {
+				k.input = k.input[:len(k.input)-1]
+			}
+		case r == terminal.KeyDeleteWord || r == terminal.KeyDeleteLine:
+			k.input = []rune{}
+		case string(r) == config.HelpInput && k.Help != "" && len(k.input) == 0:
+			k.showingHelp = true
+		case unicode.IsPrint(r):
+			k.input = append(k.input, r)
+		}
+
+		if invalid != nil {
+			if err := k.Error(config, invalid); err != nil {
+				return nil, err
+			}
+		}
+
+		// wait until everything that was pasted is in before drawing the prompt
+		if rr.Buffered() == 0 {
+			err = k.render(config)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// defaultRows returns the pairs the list starts with, in the order of their keys.
+func (k *KeyValue) defaultRows() []keyValueRow {
+	rows := []keyValueRow{}
+	for key, value := range k.prefilled().Default {
+		rows = append(rows, keyValueRow{key: key, value: value})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].key < rows[j].key })
+	return rows
+}
+
+// commit puts what the user typed into the selected row, removing the row if they
+// cleared it out.
+func (k *KeyValue) commit() error {
+	adding := k.selectedIndex == len(k.rows)
+
+	if len(k.input) == 0 {
+		if !adding {
+			k.rows = append(k.rows[:k.selectedIndex], k.rows[k.selectedIndex+1:]...)
+		}
+		return nil
+	}
+
+	row, err := k.parse(string(k.input))
+	if err != nil {
+		return err
+	}
+	// a key can only be in the list once
+	for i, other := range k.rows {
+		if i != k.selectedIndex && other.key == row.key {
+			return fmt.Errorf("%s is already in the list", row.key)
+		}
+	}
+
+	if adding {
+		k.rows = append(k.rows, row)
+	} else {
+		k.rows[k.selectedIndex] = row
+	}
+	return nil
+}
+
+// move selects another row to edit once the one the user is leaving is valid.
+func (k *KeyValue) move(step int) error {
+	// moving off of a new row that is still empty doesn't add it
+	if k.selectedIndex < len(k.rows) || len(k.input) > 0 {
+		if err := k.commit(); err != nil {
+			return err
+		}
+	}
+
+	// the empty row at the bottom is the last one to move to
+	next := k.selectedIndex + step
+	if next < 0 {
+		next = 0
+	}
+	if next > len(k.rows) {
+		next = len(k.rows)
+	}
+	k.selectedIndex = next
+	k.edit()
+	return nil
+}
+
+// remove deletes the selected row, or clears out the new one.
+func (k *KeyValue) remove() {
+	if k.selectedIndex < len(k.rows) {
+		k.rows = append(k.rows[:k.selectedIndex], k.rows[k.selectedIndex+1:]...)
+	}
+	k.edit()
+}
+
+// edit starts editing the selected row.
+func (k *KeyValue) edit() {
+	k.input = []rune{}
+	if k.selectedIndex < len(k.rows) {
+		k.input = []rune(k.rows[k.selectedIndex].String())
+	}
+}
+
+// parse splits a row into its key and value, which are checked with the validators.
+func (k *KeyValue) parse(text string) (keyValueRow, error) {
+	key, value := text, ""
+	if eq := strings.Index(text, "="); eq >= 0 {
+		key, value = text[:eq], text[eq+1:]
+	}
+
+	row := keyValueRow{key: strings.TrimSpace(key), value: value}
+	if row.key == "" {
+		return row, errors.New("every row needs a key")
+	}
+	if k.ValidateKey != nil {
+		if err := k.ValidateKey(row.key); err != nil {
+			return row, err
+		}
+	}
+	if k.ValidateValue != nil {
+		if err := k.ValidateValue(row.value); err != nil {
+			return row, err
+		}
+	}
+	return row, nil
+}
+
+// answer returns the pairs in the list.
+func (k *KeyValue) answer() map[string]string {
+	pairs := map[string]string{}
+	for _, row := range k.rows {
+		pairs[row.key] = row.value
+	}
+	return pairs
+}
+
+func (k *KeyValue) render(config *PromptConfig) error {
+	// the cursor is only shown when typing on the new row at the bottom
+	cursor := k.NewCursor()
+	if k.selectedIndex == len(k.rows) {
+		cursor.Show()
+	} else {
+		cursor.Hide()
+	}
+
+	rows := make([]string, 0, len(k.rows))
+	for _, row := range k.rows {
+		rows = append(rows, row.String())
+	}
+
+	return k.Render(
+		KeyValueQuestionTemplate,
+		KeyValueTemplateData{
+			KeyValue:      *k,
+			Rows:          rows,
+			SelectedIndex: k.selectedIndex,
+			Input:         string(k.input),
+			ShowHelp:      k.showingHelp,
+			Config:        config,
+		},
+	)
+}
+
+// DefaultAnswer returns the pairs the user would get by just pressing enter.
+func (k *KeyValue) DefaultAnswer() (interface{}, error) {
+	dflt := k.prefilled().Default
+	if len(dflt) == 0 {
+		return nil, ErrNoDefault
+	}
+	pairs := map[string]string{}
+	for key, value := range dflt {
+		pairs[key] = value
+	}
+	return pairs, nil
+}
+
+// ConvertAnswer turns a supplied answer into pairs. The answer can be a map with string
+// keys, a list of key=value rows or a string of them separated by commas, and has to
+// pass the validators like the rows the user types.
+func (k *KeyValue) ConvertAnswer(value interface{}) (interface{}, error) {
+	texts := []string{}
+	switch val := value.(type) {
+	case map[string]string:
+		for key, value := range val {
+			texts = append(texts, keyValueRow{key: key, value: value}.String())
+		}
+	case map[string]interface{}:
+		for key, value := range val {
+			texts = append(texts, keyValueRow{key: key, value: fmt.Sprint(value)}.String())
+		}
+	case []string:
+		texts = val
+	case string:
+		if val != "" {
+			texts = strings.Split(val, ",")
+		}
+	default:
+		return nil, fmt.Errorf("cannot read key/value pairs from a %T", value)
+	}
+
+	pairs := map[string]string{}
+	for _, text := range texts {
+		row, err := k.parse(text)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := pairs[row.key]; ok {
+			return nil, fmt.Errorf("%s is in the list more than once", row.key)
+		}
+		pairs[row.key] = row.value
+	}
+	return pairs, nil
+}
+
+// prefill makes an earlier answer the default when going back to the question, or
+// goes back to Default when there is none.
+func (k *KeyValue) prefill(ans interface{}) {
+	k.previous = nil
+	if val, ok := ans.(map[string]string); ok {
+		k.previous = val
+	}
+}
+
+// prefilled is the prompt as the user sees it, with the earlier answer in place of
+// Default.
+func (k *KeyValue) prefilled() KeyValue {
+	prompt := *k
+	if k.previous != nil {
+		prompt.Default = k.previous
+	}
+	return prompt
+}
+
+// formatAnswer returns the answer the way it is shown once the question is answered.
+func (k *KeyValue) formatAnswer(val interface{}) string {
+	// show the pairs in the order of their keys
+	pairs, _ := val.(map[string]string)
+	keys := make([]string, 0, len(pairs))
+	for key := range pairs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	rows := make([]string, 0, len(keys))
+	for _, key := range keys {
+		rows = append(rows, keyValueRow{key: key, value: pairs[key]}.String())
+	}
+	return strings.Join(rows, ", ")
+}
+
+func (k *KeyValue) Cleanup(config *PromptConfig, val interface{}) error {
+	return k.Render(
+		KeyValueQuestionTemplate,
+		KeyValueTemplateData{
+			KeyValue:   *k,
+			Answer:     k.formatAnswer(val),
+			ShowAnswer: true,
+			Config:     config,
+		},
+	)
+}
@@ -0,0 +1,198 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	expect "github.com/Netflix/go-expect"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	// disable color output for all prompts to simplify testing
+	core.DisableColor = true
+}
+
+func TestKeyValueRender(t *testing.T) {
+	tests := []struct {
+		title    string
+		prompt   KeyValue
+		data     KeyValueTemplateData
+		expected string
+	}{
+		{
+			"Test KeyValue question output",
+			KeyValue{Message: "Which labels?"},
+			KeyValueTemplateData{Input: "env=pr"},
+			fmt.Sprintf(
+				"%s Which labels?  [Type key=value and enter to add, enter on an empty row to submit]\n%s env=pr",
+				defaultIcons().Question.Text,
+				defaultIcons().SelectFocus.Text,
+			),
+		},
+		{
+			"Test KeyValue question output while editing a row",
+			KeyValue{Message: "Which labels?", Help: "This is helpful"},
+			KeyValueTemplateData{Rows: []string{"env=prod", "team=search"}, SelectedIndex: 0, Input: "env=dev"},
+			strings.Join(
+				[]string{
+					fmt.Sprintf("%s Which labels?  [Use arrows to edit, delete to remove, enter on an empty row to submit, ? for more help]", defaultIcons().Question.Text),
+					fmt.Sprintf("%s env=dev", defaultIcons().SelectFocus.Text),
+					"  team=search",
+					"",
+				},
+				"\n",
+			),
+		},
+		{
+			"Test KeyValue answer output",
+			KeyValue{Message: "Which labels?"},
+			KeyValueTemplateData{Answer: "env=prod, team=search", ShowAnswer: true},
+			fmt.Sprintf("%s Which labels? env=prod, team=search\n", defaultIcons().Question.Text),
+		},
+	}
+
+	for _, test := range tests {
+		test.data.KeyValue = test.prompt
+
+		// set the icon set
+		test.data.Config = defaultPromptConfig()
+
+		actual, err := core.RunTemplate(
+			KeyValueQuestionTemplate,
+			&test.data,
+		)
+		assert.Nil(t, err, test.title)
+		assert.Equal(t, test.expected, actual, test.title)
+	}
+}
+
+func TestKeyValuePrompt(t *testing.T) {
+	noSpaces := func(val interface{}) error {
+		if strings.Contains(val.(string), " ") {
+			return errors.New("keys can't have spaces")
+		}
+		return nil
+	}
+
+	tests := []PromptTest{
+		{
+			"adding rows",
+			&KeyValue{Message: "Which labels?"},
+			func(c *expect.Console) {
+				c.ExpectString("Which labels?")
+				c.SendLine("env=prod")
+				c.ExpectString("env=prod")
+				c.SendLine("team=search")
+				c.ExpectString("team=search")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			map[string]string{"env": "prod", "team": "search"},
+		},
+		{
+			"editing a row",
+			&KeyValue{
+				Message: "Which labels?",
+				Default: map[string]string{"env": "dev", "team": "search"},
+			},
+			func(c *expect.Console) {
+				c.ExpectString("team=search")
+				// go back to the first row and change it
+				c.Send(string(terminal.KeyArrowUp))
+				c.Send(string(terminal.KeyArrowUp))
+				c.ExpectString(fmt.Sprintf("%s env=dev", defaultIcons().SelectFocus.Text))
+				c.Send(strings.Repeat(string(terminal.KeyBackspace), 3))
+				c.SendLine("prod")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			map[string]string{"env": "prod", "team": "search"},
+		},
+		{
+			"removing rows",
+			&KeyValue{
+				Message: "Which labels?",
+				Default: map[string]string{"env": "dev", "team": "search", "tier": "web"},
+			},
+			func(c *expect.Console) {
+				c.ExpectString("tier=web")
+				// delete the last row
+				c.Send(string(terminal.KeyArrowUp))
+				c.Send("\x1b[3~")
+				// and clear out the first one
+				c.Send(string(terminal.KeyArrowUp))
+				c.Send(string(terminal.KeyArrowUp))
+				c.ExpectString(fmt.Sprintf("%s env=dev", defaultIcons().SelectFocus.Text))
+				c.Send(string(terminal.KeyDeleteLine))
+				c.SendLine("")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			map[string]string{"team": "search"},
+		},
+		{
+			"invalid rows",
+			&KeyValue{
+				Message:     "Which labels?",
+				Default:     map[string]string{"env": "dev"},
+				ValidateKey: noSpaces,
+			},
+			func(c *expect.Console) {
+				c.ExpectString("Which labels?")
+				c.SendLine("the team=search")
+				c.ExpectString("keys can't have spaces")
+				c.Send(string(terminal.KeyDeleteLine))
+				c.SendLine("env=prod")
+				c.ExpectString("env is already in the list")
+				c.Send(string(terminal.KeyDeleteLine))
+				c.SendLine("team=search")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			map[string]string{"env": "dev", "team": "search"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			RunPromptTest(t, test)
+		})
+	}
+}
+
+func TestKeyValueConvertAnswer(t *testing.T) {
+	prompt := &KeyValue{}
+
+	for _, value := range []interface{}{
+		map[string]string{"env": "prod", "replicas": "3"},
+		map[string]interface{}{"env": "prod", "replicas": 3},
+		[]string{"env=prod", "replicas=3"},
+		"env=prod,replicas=3",
+	} {
+		answer, err := prompt.ConvertAnswer(value)
+		assert.Nil(t, err, "converting %v", value)
+		assert.Equal(t, map[string]string{"env": "prod", "replicas": "3"}, answer, "converting %v", value)
+	}
+
+	for _, value := range []interface{}{[]string{"env=prod", "env=dev"}, "=prod", 3} {
+		_, err := prompt.ConvertAnswer(value)
+		assert.NotNil(t, err, "converting %v", value)
+	}
+}
+
+func TestAskOne_keyValue(t *testing.T) {
+	prompt := &KeyValue{}
+	answers := WithAnswers(map[string]interface{}{"": "cpu=2,memory=512"})
+
+	in, out, closeStdio := pipeStdio(t)
+	defer closeStdio()
+	limits := map[string]int{}
+	err := AskOne(prompt, &limits, WithStdio(in, out, out), answers)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{"cpu": 2, "memory": 512}, limits)
+}
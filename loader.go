@@ -0,0 +1,175 @@
+package survey
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+// LoadOptions loads the options that match what the user typed to filter them, like
+// the results of a search. It is called again every time the filter changes, and the
+// context is cancelled once the options it would return are no longer wanted.
+type LoadOptions func(ctx context.Context, filter string) ([]string, error)
+
+// loadResult holds what a call to LoadOptions came back with.
+type loadResult struct {
+	options []string
+	err     error
+}
+
+// optionLoader calls the LoadOptions of a prompt in the background as the filter
+// changes, keeping only what came back for the latest filter. Every option it has seen
+// keeps the index it was first loaded at, so answers picked under one filter still
+// point to the same option under another.
+type optionLoader struct {
+	load LoadOptions
+	// wakes up the prompt waiting for the user when there is something new to show
+	wake chan struct{}
+
+	mu     sync.Mutex
+	filter string
+	cancel context.CancelFunc
+	latest *loadResult
+
+	// if the options for the filter haven't come back yet, or why they couldn't be loaded
+	loading bool
+	err     error
+	// every option loaded so far, and where to find them
+	all     []string
+	indices map[string]int
+	// the options loaded for the filter
+	view []core.OptionAnswer
+}
+
+func newOptionLoader(load LoadOptions) *optionLoader {
+	return &optionLoader{
+		load:    load,
+		wake:    make(chan struct{}, 1),
+		indices: map[string]int{},
+		view:    []core.OptionAnswer{},
+	}
+}
+
+// start loads the options for the filter, giving up on the ones still loading for the
+// filter before it.
+func (l *optionLoader) start(filter string) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	l.mu.Lock()
+	if l.cancel != nil {
+		l.cancel()
+	}
+	l.filter, l.cancel, l.latest = filter, cancel, nil
+	l.mu.Unlock()
+	l.loading, l.err = true, nil
+
+	go func() {
+		options, err := l.load(ctx, filter)
+
+		l.mu.Lock()
+		// anything that comes back for an older filter is stale
+		stale := ctx.Err() != nil || filter != l.filter
+		if !stale {
+			l.latest = &loadResult{options: options, err: err}
+		}
+		l.mu.Unlock()
+		if stale {
+			return
+		}
+
+		// let the prompt know without waiting on it, one wake up is enough for any
+		// number of results
+		select {
+		case l.wake <- struct{}{}:
+		default:
+		}
+	}()
+}
+
+// stop gives up on the options that are still loading.
+func (l *optionLoader) stop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.cancel != nil {
+		l.cancel()
+		l.cancel = nil
+	}
+}
+
+// receive takes in what came back for the filter, returning false if nothing has.
+func (l *optionLoader) receive() bool {
+	l.mu.Lock()
+	result := l.latest
+	l.latest = nil
+	l.mu.Unlock()
+	if result == nil {
+		return false
+	}
+
+	l.loading, l.err = false, result.err
+	if result.err != nil {
+		// there is nothing to pick from if the options couldn't be loaded
+		l.view = []core.OptionAnswer{}
+		return true
+	}
+	l.add(result.options)
+	return true
+}
+
+// loadNow loads the options for the filter and waits for them, for when there is
+// nobody to show them to while they load.
+func (l *optionLoader) loadNow(filter string) error {
+	options, err := l.load(context.Background(), filter)
+	if err != nil {
+		return err
+	}
+	l.add(options)
+	return nil
+}
+
+// add makes the options the ones for the filter, remembering the ones we haven't seen.
+func (l *optionLoader) add(options []string) {
+	l.view = make([]core.OptionAnswer, 0, len(options))
+	for _, opt := range options {
+		l.view = append(l.view, core.OptionAnswer{Value: opt, Index: l.remember(opt)})
+	}
+}
+
+// remember returns the index of an option, giving it the next one if we haven't seen it.
+func (l *optionLoader) remember(option string) int {
+	idx, ok := l.indices[option]
+	if !ok {
+		idx = len(l.all)
+		l.indices[option] = idx
+		l.all = append(l.all, option)
+	}
+	return idx
+}
+
+// errLoadOutsideAsk is returned by prompts that load their options when they aren't
+// asked with Ask or AskOne.
+var errLoadOutsideAsk = errors.New("options can only be loaded by prompts asked with Ask or AskOne")
+
+// wakeReader returns the input to read keys from while options load, which stops
+// waiting on the user when the loader has something to show. The returned function
+// puts the input back the way it was. Only the reader Ask sets up can be woken, since
+// it keeps reading after the prompt is done. Starting another read here would leave
+// it waiting on the terminal to swallow whatever is typed next.
+func (l *optionLoader) wakeReader(in terminal.FileReader) (terminal.FileReader, func(), error) {
+	reader, ok := in.(*cancelableReader)
+	if !ok {
+		return nil, nil, errLoadOutsideAsk
+	}
+	previous := reader.wake
+	reader.wake = l.wake
+	return reader, func() { reader.wake = previous }, nil
+}
+
+// loadsOptions is implemented by the prompts that can load their options in the
+// background, which need to be read from with a reader they can wake up.
+type loadsOptions interface {
+	loadsOptions() bool
+}
@@ -0,0 +1,93 @@
+package survey
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/stretchr/testify/assert"
+)
+
+// loadColors loads the first few colors, or the ones that start with the filter like
+// a search would.
+func loadColors(ctx context.Context, filter string) ([]string, error) {
+	colors := []string{"red", "blue", "green", "grey", "gold"}
+	if filter == "" {
+		return colors[:3], nil
+	}
+
+	options := []string{}
+	for _, color := range colors {
+		if strings.HasPrefix(color, filter) {
+			options = append(options, color)
+		}
+	}
+	return options, nil
+}
+
+func TestOptionLoader(t *testing.T) {
+	// loads that only finish when we say so
+	release := map[string]chan error{"a": make(chan error), "ab": make(chan error, 1)}
+	loader := newOptionLoader(func(ctx context.Context, filter string) ([]string, error) {
+		err := <-release[filter]
+		return []string{filter + "1", filter + "2"}, err
+	})
+
+	loader.start("a")
+	loader.start("ab")
+	assert.True(t, loader.loading)
+	assert.False(t, loader.receive())
+
+	// the options for the filter come back
+	release["ab"] <- nil
+	<-loader.wake
+	assert.True(t, loader.receive())
+	assert.False(t, loader.loading)
+	assert.Equal(t, []core.OptionAnswer{{Value: "ab1", Index: 0}, {Value: "ab2", Index: 1}}, loader.view)
+
+	// and the ones for the filter before it are thrown away
+	release["a"] <- nil
+	assert.False(t, loader.receive())
+
+	// options keep their index when they are loaded again
+	loader.start("a")
+	release["a"] <- errors.New("offline")
+	<-loader.wake
+	assert.True(t, loader.receive())
+	assert.EqualError(t, loader.err, "offline")
+	assert.Empty(t, loader.view)
+	release["ab"] <- nil
+	assert.Nil(t, loader.loadNow("ab"))
+	assert.Equal(t, []core.OptionAnswer{{Value: "ab1", Index: 0}, {Value: "ab2", Index: 1}}, loader.view)
+}
+
+func TestOptionLoader_wakeReader(t *testing.T) {
+	r, w, err := os.Pipe()
+	assert.Nil(t, err)
+	defer r.Close()
+	defer w.Close()
+
+	// only the reader Ask sets up can be woken
+	loader := newOptionLoader(loadColors)
+	_, _, err = loader.wakeReader(r)
+	assert.Equal(t, errLoadOutsideAsk, err)
+
+	in, restore, err := loader.wakeReader(newCancelableReader(context.Background(), r))
+	assert.Nil(t, err)
+	defer restore()
+
+	// waiting on the user stops when the loader has something to show
+	loader.start("")
+	buf := make([]byte, 8)
+	_, err = in.Read(buf)
+	assert.Equal(t, errWoken, err)
+
+	// and picks up where it left off when reading again
+	w.Write([]byte("x"))
+	n, err := in.Read(buf)
+	assert.Nil(t, err)
+	assert.Equal(t, "x", string(buf[:n]))
+}
@@ -0,0 +1,364 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+/*
+MaskedInput is a text input that has to match a pattern, like a phone number or an IP
+address. The characters of the mask that aren't slots are filled in for the user, and
+each slot only accepts the kind of character it stands for:
+
+	#  a digit
+	9  a digit that can be left out by typing what comes after it
+	A  a letter
+	*  a letter or a digit
+	H  a hexadecimal digit
+	\  makes the character after it stand for itself
+
+Response type is a string, formatted like the mask unless Raw is set, in which case it
+only holds the characters that were typed in the slots.
+
+	phone := ""
+	prompt := &survey.MaskedInput{
+		Message: "What is your phone number?",
+		Mask:    "(###) ###-####",
+	}
+	survey.AskOne(prompt, &phone)
+*/
+type MaskedInput struct {
+	Renderer
+	Message     string
+	Mask        string
+	Default     string
+	Help        string
+	Raw         bool
+	Placeholder rune
+	parts       []maskPart
+	values      []rune
+	position    int
+	showingHelp bool
+	// the answer given last time, when going back to the question
+	previous *string
+}
+
+// MaskedInputTemplateData is the data available to the templates when processing
+type MaskedInputTemplateData struct {
+	MaskedInput
+	// the mask with its slots shown as the placeholder, which the answer is typed over
+	Blank      string
+	Answer     string
+	ShowAnswer bool
+	ShowHelp   bool
+	Config     *PromptConfig
+}
+
+// MaskedInputQuestionTemplate is a template with color formatting. See Documentation: https://github.com/mgutz/ansi#style-format
+var MaskedInputQuestionTemplate = `
+{{- if .ShowHelp }}{{- color .Config.Icons.Help.Format }}{{ .Config.Icons.Help.Text }} {{ .Help }}{{color "reset"}}{{"\n"}}{{end}}
+{{- color .Config.Icons.Question.Format }}{{ .Config.Icons.Question.Text }} {{color "reset"}}
+{{- color "default+hb"}}{{ .Message }} {{color "reset"}}
+{{- if .ShowAnswer}}
+  {{- color "cyan"}}{{.Answer}}{{color "reset"}}{{"\n"}}
+{{- else }}
+  {{- if and .Help (not .ShowHelp)}}{{color "cyan"}}[{{ .Config.HelpInput }} for help]{{color "reset"}} {{end}}
+  {{- if .Default}}{{color "white"}}({{.Default}}) {{color "reset"}}{{end}}
+  {{- color "white"}}{{.Blank}}{{color "reset"}}
+{{- end}}`
+
+// maskPart is a character of the mask, which is either a literal or a slot to type in.
+type maskPart struct {
+	literal  rune
+	accepts  func(rune) bool
+	optional bool
+}
+
+// the kinds of characters the slots of a mask accept
+var maskSlots = map[rune]maskPart{
+	'#': {accepts: isDigit},
+	'9': {accepts: isDigit, optional: true},
+	'A': {accepts: unicode.IsLetter},
+	'*': {accepts: func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }},
+	'H': {accepts: func(r rune) bool { return isDigit(r) || strings.ContainsRune("abcdefABCDEF", r) }},
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// parseMask splits a mask into its literals and slots.
+func parseMask(mask string) []maskPart {
+	parts := []maskPart{}
+	escaped := false
+	for _, r := range mask {
+		if slot, ok := maskSlots[r]; ok && !escaped {
+			parts = append(parts, slot)
+			continue
+		}
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		parts = append(parts, maskPart{literal: r})
+	}
+	return parts
+}
+
+// errMaskHelp stops reading a line so the help can be shown
+var errMaskHelp = errors.New("help")
+
+func (m *MaskedInput) Prompt(config *PromptConfig) (interface{}, error) {
+	m.showingHelp = false
+
+	// ask the question
+	err := m.render(config)
+	if err != nil {
+		return "", err
+	}
+
+	rr := m.NewRuneReader()
+	rr.SetTermMode()
+	defer rr.RestoreTermMode()
+
+	line := []rune{}
+	for {
+		line, err = rr.ReadLineFiltered(0, line, m.filter, m.onRune(config))
+		switch {
+		case err == errMaskHelp:
+			m.showingHelp = true
+		case err != nil:
+			return "", err
+		default:
+			// the terminal echoed the \n so we need to jump back up one row
+			m.NewCursor().PreviousLine(1)
+
+			// if the user didn't type anything they get the default, if there is one
+			if len(line) == 0 && m.prefilled().Default != "" {
+				return m.DefaultAnswer()
+			}
+			ans, invalid := m.answer(line)
+			// if the user filled in the mask we're done
+			if invalid == nil {
+				return ans, nil
+			}
+			// otherwise tell them what's wrong and let them fix it
+			if err := m.Error(config, invalid); err != nil {
+				return "", err
+			}
+		}
+
+		err = m.render(config)
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// onRune stops reading the line when the user asks for help.
+func (m *MaskedInput) onRune(config *PromptConfig) terminal.OnRuneFn {
+	return func(key rune, line []rune) ([]rune, bool, error) {
+		if string(key) == config.HelpInput && m.Help != "" {
+			return line, true, errMaskHelp
+		}
+		return line, false, nil
+	}
+}
+
+// filter only lets a key be typed where it fits the mask, filling in the literals on the
+// way to the slot that takes it. At the end of the line, the literals that follow the
+// slot are filled in too so the cursor moves on to the next one.
+func (m *MaskedInput) filter(key rune, line []rune, index int) []rune {
+	if !m.fit(line[:index]) {
+		return nil
+	}
+	start := len([]rune(m.format(m.position, false)))
+	if !m.put(key) {
+		return nil
+	}
+	end := m.position
+	if index == len(line) {
+		for end < len(m.parts) && m.parts[end].accepts == nil {
+			end++
+		}
+	}
+	typed := []rune(m.format(end, false))[start:]
+
+	// typing in the middle of the line can't push what comes after it out of the mask
+	if index < len(line) {
+		edited := append(append(append([]rune{}, line[:index]...), typed...), line[index:]...)
+		if !m.fit(edited) {
+			return nil
+		}
+	}
+	return typed
+}
+
+// reset empties the mask so the user can start over.
+func (m *MaskedInput) reset() {
+	m.parts = parseMask(m.Mask)
+	m.values = make([]rune, len(m.parts))
+	m.position = 0
+}
+
+// put types a character into the next slot that accepts it, jumping over the literals
+// and optional slots in the way. Typing a literal moves past it. It returns false if the
+// character doesn't fit.
+func (m *MaskedInput) put(r rune) bool {
+	for i := m.position; i < len(m.parts); i++ {
+		part := m.parts[i]
+		switch {
+		case part.accepts == nil && part.literal == r:
+			m.position = i + 1
+			return true
+		case part.accepts == nil:
+			continue
+		case part.accepts(r):
+			m.values[i] = r
+			m.position = i + 1
+			return true
+		case !part.optional:
+			return false
+		}
+	}
+	return false
+}
+
+// fit types a line into the mask one character at a time. It returns false if one of
+// them doesn't fit.
+func (m *MaskedInput) fit(line []rune) bool {
+	m.reset()
+	for _, r := range line {
+		if !m.put(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// format returns what the user has typed up to the given part of the mask, with the
+// literals filled in unless they only want the raw characters.
+func (m *MaskedInput) format(end int, raw bool) string {
+	out := []rune{}
+	for i, part := range m.parts[:end] {
+		if part.accepts == nil {
+			if !raw {
+				out = append(out, part.literal)
+			}
+			continue
+		}
+		if m.values[i] != 0 {
+			out = append(out, m.values[i])
+		}
+	}
+	return string(out)
+}
+
+// answer returns the value of a line typed into the mask, or why it doesn't fill it in.
+func (m *MaskedInput) answer(line []rune) (string, error) {
+	if !m.fit(line) {
+		return "", fmt.Errorf("%q does not fit %s", string(line), m.Mask)
+	}
+	// the rest of the mask has to be literals or slots that can be left out
+	for _, part := range m.parts[m.position:] {
+		if part.accepts != nil && !part.optional {
+			return "", fmt.Errorf("the answer has to look like %s", m.Mask)
+		}
+	}
+	// fill in the literals that close the mask
+	return m.format(len(m.parts), m.Raw), nil
+}
+
+func (m *MaskedInput) render(config *PromptConfig) error {
+	placeholder := m.Placeholder
+	if placeholder == 0 {
+		placeholder = '_'
+	}
+	blank := []rune{}
+	for _, part := range parseMask(m.Mask) {
+		if part.accepts == nil {
+			blank = append(blank, part.literal)
+		} else {
+			blank = append(blank, placeholder)
+		}
+	}
+
+	err := m.Render(
+		MaskedInputQuestionTemplate,
+		MaskedInputTemplateData{
+			MaskedInput: m.prefilled(),
+			Blank:       string(blank),
+			ShowHelp:    m.showingHelp,
+			Config:      config,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// move the cursor back to the start of the mask, where the line is read
+	if len(blank) > 0 {
+		m.NewCursor().Back(len(blank))
+	}
+	return nil
+}
+
+// DefaultAnswer returns the answer the user would get by just pressing enter.
+func (m *MaskedInput) DefaultAnswer() (interface{}, error) {
+	dflt := m.prefilled().Default
+	if dflt == "" {
+		return nil, ErrNoDefault
+	}
+	return m.ConvertAnswer(dflt)
+}
+
+// ConvertAnswer fits a supplied answer into the mask, which can be given with or
+// without the literals.
+func (m *MaskedInput) ConvertAnswer(value interface{}) (interface{}, error) {
+	val, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("cannot fill in a mask with a %T", value)
+	}
+	ans, err := m.answer([]rune(val))
+	if err != nil {
+		return nil, err
+	}
+	return ans, nil
+}
+
+// prefill makes an earlier answer the default when going back to the question, or
+// goes back to Default when there is none.
+func (m *MaskedInput) prefill(ans interface{}) {
+	m.previous = nil
+	if val, ok := ans.(string); ok {
+		m.previous = &val
+	}
+}
+
+// prefilled is the prompt as the user sees it, with the earlier answer in place of
+// Default.
+func (m *MaskedInput) prefilled() MaskedInput {
+	prompt := *m
+	if m.previous != nil {
+		prompt.Default = *m.previous
+	}
+	return prompt
+}
+
+func (m *MaskedInput) Cleanup(config *PromptConfig, val interface{}) error {
+	// transformers can turn the answer into something other than a string
+	return m.Render(
+		MaskedInputQuestionTemplate,
+		MaskedInputTemplateData{
+			MaskedInput: *m,
+			Answer:      fmt.Sprint(val),
+			ShowAnswer:  true,
+			Config:      config,
+		},
+	)
+}
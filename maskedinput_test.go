@@ -0,0 +1,238 @@
+package survey
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	expect "github.com/Netflix/go-expect"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	// disable color output for all prompts to simplify testing
+	core.DisableColor = true
+}
+
+func TestMaskedInputRender(t *testing.T) {
+	tests := []struct {
+		title    string
+		prompt   MaskedInput
+		data     MaskedInputTemplateData
+		expected string
+	}{
+		{
+			"Test MaskedInput question output",
+			MaskedInput{Message: "What is your phone number?", Mask: "(###) ###-####"},
+			MaskedInputTemplateData{Blank: "(___) ___-____"},
+			fmt.Sprintf("%s What is your phone number? (___) ___-____", defaultIcons().Question.Text),
+		},
+		{
+			"Test MaskedInput question output with default",
+			MaskedInput{Message: "What is your phone number?", Default: "5551234567"},
+			MaskedInputTemplateData{Blank: "(___) ___-____"},
+			fmt.Sprintf("%s What is your phone number? (5551234567) (___) ___-____", defaultIcons().Question.Text),
+		},
+		{
+			"Test MaskedInput answer output",
+			MaskedInput{Message: "What is your phone number?"},
+			MaskedInputTemplateData{Answer: "(555) 123-4567", ShowAnswer: true},
+			fmt.Sprintf("%s What is your phone number? (555) 123-4567\n", defaultIcons().Question.Text),
+		},
+	}
+
+	for _, test := range tests {
+		test.data.MaskedInput = test.prompt
+
+		// set the icon set
+		test.data.Config = defaultPromptConfig()
+
+		actual, err := core.RunTemplate(
+			MaskedInputQuestionTemplate,
+			&test.data,
+		)
+		assert.Nil(t, err, test.title)
+		assert.Equal(t, test.expected, actual, test.title)
+	}
+}
+
+func TestMaskedInputPrompt(t *testing.T) {
+	tests := []PromptTest{
+		{
+			"phone number",
+			&MaskedInput{
+				Message: "What is your phone number?",
+				Mask:    "(###) ###-####",
+			},
+			func(c *expect.Console) {
+				c.ExpectString("What is your phone number?")
+				// letters don't fit and the literals are filled in
+				c.Send("555abc123")
+				c.ExpectString("123-")
+				c.SendLine("4567")
+				c.ExpectEOF()
+			},
+			"(555) 123-4567",
+		},
+		{
+			"raw value",
+			&MaskedInput{
+				Message: "What is your phone number?",
+				Mask:    "(###) ###-####",
+				Raw:     true,
+			},
+			func(c *expect.Console) {
+				c.ExpectString("What is your phone number?")
+				// typing the literals works too
+				c.SendLine("(555) 123-4567")
+				c.ExpectEOF()
+			},
+			"5551234567",
+		},
+		{
+			"optional digits",
+			&MaskedInput{
+				Message: "Server address:",
+				Mask:    "999.999.999.999",
+			},
+			func(c *expect.Console) {
+				c.ExpectString("Server address:")
+				c.Send("10.")
+				c.ExpectString("10.")
+				// deleting the . goes back to the digits before it
+				c.Send(string(terminal.KeyBackspace))
+				c.Send(string(terminal.KeyBackspace))
+				c.SendLine("2.0.0.1")
+				c.ExpectEOF()
+			},
+			"12.0.0.1",
+		},
+		{
+			"editing in the middle",
+			&MaskedInput{
+				Message: "What is your phone number?",
+				Mask:    "(###) ###-####",
+			},
+			func(c *expect.Console) {
+				c.ExpectString("What is your phone number?")
+				c.Send("5551234567")
+				c.ExpectString("4567")
+				// go back to the 3 and change it
+				for i := 0; i < 5; i++ {
+					c.Send(string(terminal.KeyArrowLeft))
+				}
+				c.Send(string(terminal.KeyBackspace))
+				// the second 9 would push the rest out of the mask so it's ignored
+				c.Send("99")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			"(555) 129-4567",
+		},
+		{
+			"incomplete answer",
+			&MaskedInput{
+				Message: "What is your phone number?",
+				Mask:    "(###) ###-####",
+			},
+			func(c *expect.Console) {
+				c.ExpectString("What is your phone number?")
+				c.SendLine("555")
+				c.ExpectString("the answer has to look like (###) ###-####")
+				c.SendLine("1234567")
+				c.ExpectEOF()
+			},
+			"(555) 123-4567",
+		},
+		{
+			"empty answer without a default",
+			&MaskedInput{
+				Message: "What is your phone number?",
+				Mask:    "(###) ###-####",
+			},
+			func(c *expect.Console) {
+				c.ExpectString("What is your phone number?")
+				c.SendLine("")
+				c.ExpectString("the answer has to look like (###) ###-####")
+				c.SendLine("5551234567")
+				c.ExpectEOF()
+			},
+			"(555) 123-4567",
+		},
+		{
+			"default",
+			&MaskedInput{
+				Message: "What is your phone number?",
+				Mask:    "(###) ###-####",
+				Default: "5551234567",
+			},
+			func(c *expect.Console) {
+				c.ExpectString("What is your phone number?")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			"(555) 123-4567",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			RunPromptTest(t, test)
+		})
+	}
+}
+
+func TestMaskedInputConvertAnswer(t *testing.T) {
+	prompt := &MaskedInput{Mask: `HH:HH \#9`}
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"0a:FF #1", "0a:FF #1"},
+		{"0aFF1", "0a:FF #1"},
+		{"0aFF", "0a:FF #"},
+	}
+	for _, test := range tests {
+		answer, err := prompt.ConvertAnswer(test.value)
+		assert.Nil(t, err, test.value)
+		assert.Equal(t, test.expected, answer, test.value)
+	}
+
+	// an empty answer has to fill in the mask too
+	for _, value := range []string{"", "0g:00", "0a:F", "0aFF12"} {
+		_, err := prompt.ConvertAnswer(value)
+		assert.NotNil(t, err, value)
+	}
+}
+
+func TestMaskedInputCleanup(t *testing.T) {
+	tests := []struct {
+		answer   interface{}
+		expected string
+	}{
+		{"(555) 123-4567", "Phone? (555) 123-4567\n"},
+		// answers changed by a transformer are shown as they are
+		{5551234567, "Phone? 5551234567\n"},
+	}
+
+	for _, test := range tests {
+		r, w, err := os.Pipe()
+		assert.Nil(t, err)
+
+		prompt := MaskedInput{Message: "Phone?", Mask: "(###) ###-####"}
+		prompt.WithStdio(terminal.Stdio{Out: w})
+		err = prompt.Cleanup(defaultPromptConfig(), test.answer)
+		assert.Nil(t, err)
+
+		w.Close()
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+
+		assert.Contains(t, buf.String(), test.expected)
+	}
+}
@@ -11,6 +11,8 @@ type Multiline struct {
 	Message string
 	Default string
 	Help    string
+	// the answer given last time, when going back to the question
+	previous *string
 }
 
 // data available to the templates when processing
@@ -40,7 +42,7 @@ func (i *Multiline) Prompt(config *PromptConfig) (interface{}, error) {
 	err := i.Render(
 		MultilineQuestionTemplate,
 		MultilineTemplateData{
-			Multiline: *i,
+			Multiline: i.prefilled(),
 			Config:    config,
 		},
 	)
@@ -90,13 +92,41 @@ func (i *Multiline) Prompt(config *PromptConfig) (interface{}, error) {
 	// if the line is empty
 	if len(val) == 0 {
 		// use the default value
-		return i.Default, err
+		return i.prefilled().Default, err
 	}
 
 	// we're done
 	return val, err
 }
 
+// DefaultAnswer returns the answer the user would get by not typing anything.
+func (i *Multiline) DefaultAnswer() (interface{}, error) {
+	dflt := i.prefilled().Default
+	if dflt == "" {
+		return nil, ErrNoDefault
+	}
+	return dflt, nil
+}
+
+// prefill makes an earlier answer the default when going back to the question, or
+// goes back to Default when there is none.
+func (i *Multiline) prefill(ans interface{}) {
+	i.previous = nil
+	if val, ok := ans.(string); ok {
+		i.previous = &val
+	}
+}
+
+// prefilled is the prompt as the user sees it, with the earlier answer in place of
+// Default.
+func (i *Multiline) prefilled() Multiline {
+	prompt := *i
+	if i.previous != nil {
+		prompt.Default = *i.previous
+	}
+	return prompt
+}
+
 func (i *Multiline) Cleanup(config *PromptConfig, val interface{}) error {
 	return i.Render(
 		MultilineQuestionTemplate,
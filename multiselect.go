@@ -3,6 +3,7 @@ package survey
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2/core"
 	"github.com/AlecAivazis/survey/v2/terminal"
@@ -18,23 +19,43 @@ for them to select using the arrow keys and enter. Response type is a slice of s
 		Options: []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
 	}
 	survey.AskOne(prompt, &days)
+
+Choices can be given in place of Options to describe each option or disable some of them,
+and Load can load the options in the background as the user types to filter them, which
+only works when the prompt is asked with Ask or AskOne.
+MinSelected and MaxSelected limit how many options the user can check. The right arrow
+checks every option that matches the filter, the left arrow unchecks them and ctrl+e
+inverts them.
 */
 type MultiSelect struct {
 	Renderer
 	Message       string
 	Options       []string
+	Choices       []Choice
 	Default       interface{}
 	Help          string
 	PageSize      int
 	VimMode       bool
 	FilterMessage string
 	Filter        func(filter string, value string, index int) bool
+	Matcher       Matcher
+	Load          LoadOptions
+	MinSelected   int
+	MaxSelected   int
 	filter        string
+	filtered      optionFilter
+	loader        *optionLoader
 	selectedIndex int
 	checked       map[int]bool
+	notice        string
 	showingHelp   bool
+	// the answer given last time, when going back to the question
+	previous interface{}
 }
 
+// keyInvert inverts which of the options that match the filter are checked.
+const keyInvert = '\x05' // Ctrl+E
+
 // data available to the templates when processing
 type MultiSelectTemplateData struct {
 	MultiSelect
@@ -42,6 +63,12 @@ type MultiSelectTemplateData struct {
 	ShowAnswer    bool
 	Checked       map[int]bool
 	SelectedIndex int
+	Description   string
+	Headers       map[int]string
+	Highlights    map[int][]MatchSegment
+	Loading       bool
+	LoadError     error
+	Notice        string
 	ShowHelp      bool
 	PageEntries   []core.OptionAnswer
 	Config        *PromptConfig
@@ -53,43 +80,52 @@ var MultiSelectQuestionTemplate = `
 {{- color "default+hb"}}{{ .Message }}{{ .FilterMessage }}{{color "reset"}}
 {{- if .ShowAnswer}}{{color "cyan"}} {{.Answer}}{{color "reset"}}{{"\n"}}
 {{- else }}
-	{{- "  "}}{{- color "cyan"}}[Use arrows to move, enter to select, type to filter{{- if and .Help (not .ShowHelp)}}, {{ .Config.HelpInput }} for more help{{end}}]{{color "reset"}}
+	{{- "  "}}{{- color "cyan"}}[Use arrows to move, enter to select, right to check all, left to check none, ctrl+e to invert, tab to toggle a group, type to filter{{- if and .Help (not .ShowHelp)}}, {{ .Config.HelpInput }} for more help{{end}}]{{color "reset"}}
   {{- "\n"}}
   {{- range $ix, $option := .PageEntries}}
+    {{- with index $.Headers $ix}}{{color "default+hb"}}{{.}}{{color "reset"}}{{"\n"}}{{end}}
+    {{- $disabled := false}}{{if $.Choices}}{{$disabled = (index $.Choices $option.Index).Disabled}}{{end}}
     {{- if eq $ix $.SelectedIndex }}{{color $.Config.Icons.SelectFocus.Format }}{{ $.Config.Icons.SelectFocus.Text }}{{color "reset"}}{{else}} {{end}}
     {{- if index $.Checked $option.Index }}{{color $.Config.Icons.MarkedOption.Format }} {{ $.Config.Icons.MarkedOption.Text }} {{else}}{{color $.Config.Icons.UnmarkedOption.Format }} {{ $.Config.Icons.UnmarkedOption.Text }} {{end}}
     {{- color "reset"}}
-    {{- " "}}{{$option.Value}}{{"\n"}}
+    {{- " "}}{{if $disabled}}{{color "black+h"}}{{end}}
+    {{- with index $.Highlights $option.Index}}
+      {{- range .}}
+        {{- if .Matched}}{{color "cyan+bu"}}{{.Text}}{{color "reset"}}{{if $disabled}}{{color "black+h"}}{{end}}
+        {{- else}}{{.Text}}{{end}}
+      {{- end}}
+    {{- else}}{{$option.Value}}{{end}}
+    {{- if $disabled}}{{with (index $.Choices $option.Index).DisabledReason}} ({{.}}){{end}}{{color "reset"}}{{end}}{{"\n"}}
   {{- end}}
+  {{- if .Loading}}{{color "cyan"}}  Loading...{{color "reset"}}{{"\n"}}{{end}}
+  {{- with .LoadError}}{{color "red"}}  Could not load the options: {{.Error}}{{color "reset"}}{{"\n"}}{{end}}
+  {{- with .Notice}}{{color $.Config.Icons.Error.Format}}{{ $.Config.Icons.Error.Text }} {{.}}{{color "reset"}}{{"\n"}}{{end}}
+  {{- if .Description}}{{color "cyan"}}{{ .Description }}{{color "reset"}}{{"\n"}}{{end}}
 {{- end}}`
 
 // OnChange is called on every keypress.
 func (m *MultiSelect) OnChange(key rune, config *PromptConfig) {
+	m.handleKey(key, config)
+	m.render(config)
+}
+
+// handleKey updates the prompt for a key the user pressed.
+func (m *MultiSelect) handleKey(key rune, config *PromptConfig) {
 	options := m.filterOptions(config)
 	oldFilter := m.filter
+	// anything we had to say about the last key is old news
+	m.notice = ""
 
 	if key == terminal.KeyArrowUp || (m.VimMode && key == 'k') {
-		// if we are at the top of the list
-		if m.selectedIndex == 0 {
-			// go to the bottom
-			m.selectedIndex = len(options) - 1
-		} else {
-			// decrement the selected index
-			m.selectedIndex--
-		}
+		// move up, skipping the options that are disabled and wrapping around at the top
+		m.selectedIndex = focusEnabled(m.Choices, options, m.selectedIndex, -1)
 	} else if key == terminal.KeyArrowDown || (m.VimMode && key == 'j') {
-		// if we are at the bottom of the list
-		if m.selectedIndex == len(options)-1 {
-			// start at the top
-			m.selectedIndex = 0
-		} else {
-			// increment the selected index
-			m.selectedIndex++
-		}
+		// move down, skipping the options that are disabled and wrapping around at the bottom
+		m.selectedIndex = focusEnabled(m.Choices, options, m.selectedIndex, 1)
 		// if the user pressed down and there is room to move
 	} else if key == terminal.KeySpace {
-		// the option they have selected
-		if m.selectedIndex < len(options) {
+		// the option they have selected, unless it can't be picked
+		if m.selectedIndex < len(options) && !isDisabled(m.Choices, options[m.selectedIndex].Index) {
 			selectedOpt := options[m.selectedIndex]
 
 			// if we haven't seen this index before
@@ -100,8 +136,37 @@ func (m *MultiSelect) OnChange(key rune, config *PromptConfig) {
 				// otherwise just invert the current value
 				m.checked[selectedOpt.Index] = !old
 			}
-			m.filter = ""
+			// unless that checks more options than they are allowed to
+			if m.overLimit() {
+				m.checked[selectedOpt.Index] = false
+			} else {
+				m.filter = ""
+			}
 		}
+	} else if key == terminal.KeyTab {
+		// toggle every option in the group of the one they have selected
+		if m.selectedIndex < len(options) {
+			members := groupMembers(m.Choices, options[m.selectedIndex].Index)
+
+			// check the whole group unless it is already checked
+			check := false
+			for _, idx := range members {
+				if !m.checked[idx] {
+					check = true
+				}
+			}
+			if m.setChecked(members, func(bool) bool { return check }) && len(members) > 0 {
+				m.filter = ""
+			}
+		}
+	} else if key == terminal.KeyArrowRight {
+		// check every option that matches the filter
+		m.setChecked(m.enabledIndices(options), func(bool) bool { return true })
+	} else if key == terminal.KeyArrowLeft {
+		// or uncheck them
+		m.setChecked(m.enabledIndices(options), func(bool) bool { return false })
+	} else if key == keyInvert {
+		m.setChecked(m.enabledIndices(options), func(checked bool) bool { return !checked })
 		// only show the help message if we have one to show
 	} else if string(key) == config.HelpInput && m.Help != "" {
 		m.showingHelp = true
@@ -128,7 +193,14 @@ func (m *MultiSelect) OnChange(key rune, config *PromptConfig) {
 		if len(options) > 0 && len(options) <= m.selectedIndex {
 			m.selectedIndex = len(options) - 1
 		}
+		m.selectedIndex = focusEnabled(m.Choices, options, m.selectedIndex, 0)
 	}
+}
+
+func (m *MultiSelect) render(config *PromptConfig) error {
+	options := m.filterOptions(config)
+	match, _ := filterMatcher(m.Filter, m.Matcher, config)
+
 	// paginate the options
 	// figure out the page size
 	pageSize := m.PageSize
@@ -143,159 +215,422 @@ func (m *MultiSelect) OnChange(key rune, config *PromptConfig) {
 	opts, idx := paginate(pageSize, options, m.selectedIndex)
 
 	// render the options
-	m.Render(
+	return m.Render(
 		MultiSelectQuestionTemplate,
 		MultiSelectTemplateData{
 			MultiSelect:   *m,
 			SelectedIndex: idx,
+			Description:   choiceDescription(m.Choices, options, m.selectedIndex),
 			Checked:       m.checked,
 			ShowHelp:      m.showingHelp,
 			PageEntries:   opts,
+			Headers:       groupHeaders(m.Choices, opts),
+			Highlights:    m.filtered.segments(match, opts),
+			Loading:       m.loader != nil && m.loader.loading,
+			LoadError:     m.loadError(),
+			Notice:        m.notice,
 			Config:        config,
 		},
 	)
 }
 
+// filterOptions returns the options that match the filter, which also decides the group
+// headers: a group is only shown while some of its options match.
 func (m *MultiSelect) filterOptions(config *PromptConfig) []core.OptionAnswer {
-	// the filtered list
-	answers := []core.OptionAnswer{}
+	// options that are loaded have already been filtered by whoever loaded them
+	if m.loader != nil {
+		return m.loader.view
+	}
 
-	// if there is no filter applied
-	if m.filter == "" {
-		// return all of the options
-		return core.OptionAnswerList(m.Options)
+	// if we haven't seen the options yet
+	if m.filtered.all == nil {
+		m.filtered.reset(m.labels(), groupRuns(m.Choices))
 	}
 
-	// the filter to apply
-	filter := m.Filter
-	if filter == nil {
-		filter = config.Filter
+	// apply the filter to each option, best matches first
+	match, narrows := filterMatcher(m.Filter, m.Matcher, config)
+	return m.filtered.apply(match, narrows, m.filter)
+}
+
+// setChecked checks or unchecks the options with the given indices, leaving them as
+// they were if that would check more options than the user is allowed to. It returns
+// true if the options were changed.
+func (m *MultiSelect) setChecked(indices []int, check func(checked bool) bool) bool {
+	previous := map[int]bool{}
+	for _, idx := range indices {
+		previous[idx] = m.checked[idx]
+		m.checked[idx] = check(m.checked[idx])
 	}
 
-	// apply the filter to each option
-	for i, opt := range m.Options {
-		// i the filter says to include the option
-		if filter(m.filter, opt, i) {
-			answers = append(answers, core.OptionAnswer{
-				Index: i,
-				Value: opt,
-			})
+	if m.overLimit() {
+		for idx, checked := range previous {
+			m.checked[idx] = checked
 		}
+		return false
 	}
+	return true
+}
 
-	// we're done here
-	return answers
+// enabledIndices returns the indices of the options that can be checked.
+func (m *MultiSelect) enabledIndices(options []core.OptionAnswer) []int {
+	indices := []int{}
+	for _, opt := range options {
+		if !isDisabled(m.Choices, opt.Index) {
+			indices = append(indices, opt.Index)
+		}
+	}
+	return indices
 }
 
-func (m *MultiSelect) Prompt(config *PromptConfig) (interface{}, error) {
-	// compute the default state
-	m.checked = make(map[int]bool)
+// overLimit returns true if more options are checked than MaxSelected allows, and tells
+// the user so.
+func (m *MultiSelect) overLimit() bool {
+	if m.MaxSelected > 0 && len(m.answers()) > m.MaxSelected {
+		m.notice = fmt.Sprintf("You can select at most %s", optionCount(m.MaxSelected))
+		return true
+	}
+	return false
+}
+
+// limitError returns why the given number of options can't be selected.
+func (m *MultiSelect) limitError(count int) error {
+	if count < m.MinSelected {
+		return fmt.Errorf("select at least %s", optionCount(m.MinSelected))
+	}
+	if m.MaxSelected > 0 && count > m.MaxSelected {
+		return fmt.Errorf("select at most %s", optionCount(m.MaxSelected))
+	}
+	return nil
+}
+
+// optionCount describes a number of options.
+func optionCount(count int) string {
+	if count == 1 {
+		return "1 option"
+	}
+	return fmt.Sprintf("%d options", count)
+}
+
+// defaultChecked computes which options are checked before the user has done anything.
+func (m *MultiSelect) defaultChecked() map[int]bool {
+	checked := make(map[int]bool)
+	dflt := m.prefilled().Default
 	// if there is a default
-	if m.Default != nil {
+	if dflt != nil {
 		// if the default is string values
-		if defaultValues, ok := m.Default.([]string); ok {
+		if defaultValues, ok := dflt.([]string); ok {
 			for _, dflt := range defaultValues {
-				for i, opt := range m.Options {
+				// options that load might not have come back yet
+				if m.loader != nil {
+					checked[m.loader.remember(dflt)] = true
+					continue
+				}
+				for i, opt := range m.labels() {
 					// if the option corresponds to the default
 					if opt == dflt {
 						// we found our initial value
-						m.checked[i] = true
+						checked[i] = true
 						// stop looking
 						break
 					}
 				}
 			}
 			// if the default value is index values
-		} else if defaultIndices, ok := m.Default.([]int); ok {
+		} else if defaultIndices, ok := dflt.([]int); ok {
 			// go over every index we need to enable by default
 			for _, idx := range defaultIndices {
 				// and enable it
-				m.checked[idx] = true
+				checked[idx] = true
 			}
 		}
 	}
+	return checked
+}
 
-	// if there are no options to render
-	if len(m.Options) == 0 {
+// answers returns the checked options in the order they were given.
+func (m *MultiSelect) answers() []core.OptionAnswer {
+	answers := []core.OptionAnswer{}
+	for i, option := range m.labels() {
+		if val, ok := m.checked[i]; ok && val {
+			answers = append(answers, choiceAnswer(m.Choices, core.OptionAnswer{Value: option, Index: i}))
+		}
+	}
+	return answers
+}
+
+// DefaultAnswer returns the answer the user would get by accepting the prompt
+// without checking or unchecking anything.
+func (m *MultiSelect) DefaultAnswer() (interface{}, error) {
+	if err := m.loadOptions(); err != nil {
+		return "", err
+	}
+	// if there are no options to choose from
+	if len(m.labels()) == 0 {
 		// we failed
 		return "", errors.New("please provide options to select from")
 	}
 
-	// figure out the page size
-	pageSize := m.PageSize
-	// if we dont have a specific one
-	if pageSize == 0 {
-		// grab the global value
-		pageSize = config.PageSize
+	m.checked = m.defaultChecked()
+	answers := m.answers()
+	// the user has to pick the options themselves if there is no default to start from
+	if len(answers) == 0 && m.MinSelected > 0 && m.prefilled().Default == nil {
+		return nil, ErrNoDefault
+	}
+	if err := m.limitError(len(answers)); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+func (m *MultiSelect) Prompt(config *PromptConfig) (interface{}, error) {
+	// options that load in the background start off empty
+	m.loader = nil
+	stdio := m.Stdio()
+	if m.Load != nil {
+		m.loader = newOptionLoader(m.Load)
+		// read keys with a reader the loader can wake up when the options come back
+		in, restore, err := m.loader.wakeReader(stdio.In)
+		if err != nil {
+			return "", err
+		}
+		defer restore()
+		stdio.In = in
+		m.loader.start("")
+		defer m.loader.stop()
+	}
+
+	// compute the default state
+	m.checked = m.defaultChecked()
+
+	// if there are no options to render
+	if len(m.labels()) == 0 && m.loader == nil {
+		// we failed
+		return "", errors.New("please provide options to select from")
 	}
-	// paginate the options
-	// build up a list of option answers
-	opts, idx := paginate(pageSize, core.OptionAnswerList(m.Options), m.selectedIndex)
+
+	// start from an option that isn't disabled
+	m.filtered.reset(m.labels(), groupRuns(m.Choices))
+	options := m.filterOptions(config)
+	m.selectedIndex = focusEnabled(m.Choices, options, m.selectedIndex, 0)
 
 	cursor := m.NewCursor()
 	cursor.Hide()       // hide the cursor
 	defer cursor.Show() // show the cursor when we're done
 
 	// ask the question
-	err := m.Render(
-		MultiSelectQuestionTemplate,
-		MultiSelectTemplateData{
-			MultiSelect:   *m,
-			SelectedIndex: idx,
-			Checked:       m.checked,
-			PageEntries:   opts,
-			Config:        config,
-		},
-	)
+	err := m.render(config)
 	if err != nil {
 		return "", err
 	}
 
-	rr := m.NewRuneReader()
+	rr := terminal.NewRuneReader(stdio)
 	rr.SetTermMode()
 	defer rr.RestoreTermMode()
 
 	// start waiting for input
 	for {
-		r, _, _ := rr.ReadRune()
+		r, _, err := rr.ReadRune()
+		if err == errWoken {
+			// the options for the filter came back
+			if m.loader.receive() {
+				m.showLoaded(config)
+			}
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
 		if r == '\r' || r == '\n' {
+			// the user can't submit until they have checked enough options
+			if len(m.answers()) < m.MinSelected {
+				m.notice = fmt.Sprintf("Select at least %s", optionCount(m.MinSelected))
+				m.render(config)
+				continue
+			}
+			if m.overLimit() {
+				m.render(config)
+				continue
+			}
 			break
 		}
 		if r == terminal.KeyInterrupt {
 			return "", terminal.InterruptErr
 		}
+		if r == terminal.SpecialKeyShiftTab {
+			return "", terminal.GoBackErr
+		}
 		if r == terminal.KeyEndTransmission {
 			break
 		}
-		m.OnChange(r, config)
+		filter := m.filter
+		m.handleKey(r, config)
+		if m.loader != nil && m.filter != filter {
+			m.loader.start(m.filter)
+		}
+		// wait until the keys the user typed ahead are handled before drawing the prompt
+		if rr.Buffered() == 0 {
+			m.render(config)
+		}
 	}
 	m.filter = ""
 	m.FilterMessage = ""
 
-	answers := []core.OptionAnswer{}
-	for i, option := range m.Options {
-		if val, ok := m.checked[i]; ok && val {
-			answers = append(answers, core.OptionAnswer{Value: option, Index: i})
+	return m.answers(), nil
+}
+
+// showLoaded shows the options that were loaded for the filter, keeping the cursor in
+// the list.
+func (m *MultiSelect) showLoaded(config *PromptConfig) {
+	options := m.filterOptions(config)
+	if len(options) > 0 && len(options) <= m.selectedIndex {
+		m.selectedIndex = len(options) - 1
+	}
+	m.render(config)
+}
+
+// loadError returns why the options for the filter couldn't be loaded.
+func (m *MultiSelect) loadError() error {
+	if m.loader == nil {
+		return nil
+	}
+	return m.loader.err
+}
+
+// loadOptions loads the options up front if they come from Load, for answering the
+// question without the user.
+func (m *MultiSelect) loadOptions() error {
+	if m.Load == nil || m.loader != nil {
+		return nil
+	}
+	loader := newOptionLoader(m.Load)
+	if err := loader.loadNow(""); err != nil {
+		return err
+	}
+	m.loader = loader
+	return nil
+}
+
+func (m *MultiSelect) loadsOptions() bool {
+	return m.Load != nil
+}
+
+// ConvertAnswer turns a supplied answer into the options it names. The answer can be
+// a list of option values or indices, a []core.OptionAnswer, or a single option, and
+// has to name between MinSelected and MaxSelected options.
+func (m *MultiSelect) ConvertAnswer(value interface{}) (interface{}, error) {
+	if err := m.loadOptions(); err != nil {
+		return nil, err
+	}
+	// options that load might only show up when filtering for them
+	if vals, ok := value.([]string); ok && m.loader != nil {
+		for _, val := range vals {
+			if _, seen := m.loader.indices[val]; seen {
+				continue
+			}
+			if err := m.loader.loadNow(val); err != nil {
+				return nil, err
+			}
+		}
+	}
+	answers, err := findOptions(m.Choices, m.labels(), value)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.limitError(len(answers)); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+// labels returns the options to show, which are the labels of the choices if there are any
+// or every option that was loaded.
+func (m *MultiSelect) labels() []string {
+	if m.loader != nil {
+		return m.loader.all
+	}
+	if len(m.Choices) > 0 {
+		return choiceLabels(m.Choices)
+	}
+	return m.Options
+}
+
+// findOptions looks up each of the values in a list of options, which can be given as
+// values, indices or OptionAnswers. A single value is treated as a list of one.
+func findOptions(choices []Choice, options []string, value interface{}) ([]core.OptionAnswer, error) {
+	var values []interface{}
+	switch val := value.(type) {
+	case []interface{}:
+		values = val
+	case []string:
+		for _, v := range val {
+			values = append(values, v)
+		}
+	case []int:
+		for _, v := range val {
+			values = append(values, v)
 		}
+	case []core.OptionAnswer:
+		for _, v := range val {
+			values = append(values, v)
+		}
+	default:
+		values = []interface{}{value}
 	}
 
+	answers := []core.OptionAnswer{}
+	for _, v := range values {
+		ans, err := findChoice(choices, options, v)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, ans)
+	}
 	return answers, nil
 }
 
-// Cleanup removes the options section, and renders the ask like a normal question.
-func (m *MultiSelect) Cleanup(config *PromptConfig, val interface{}) error {
-	// the answer to show
-	answer := ""
-	for _, ans := range val.([]core.OptionAnswer) {
-		answer = fmt.Sprintf("%s, %s", answer, ans.Value)
+// prefill makes an earlier answer the default when going back to the question, or
+// goes back to Default when there is none.
+func (m *MultiSelect) prefill(ans interface{}) {
+	m.previous = nil
+	if vals, ok := ans.([]core.OptionAnswer); ok {
+		// the options that load are given new indices every time, so go by name
+		if m.Load != nil {
+			values := []string{}
+			for _, val := range vals {
+				values = append(values, val.Value)
+			}
+			m.previous = values
+			return
+		}
+		indices := []int{}
+		for _, val := range vals {
+			indices = append(indices, val.Index)
+		}
+		m.previous = indices
 	}
+}
 
-	// if we answered anything
-	if len(answer) > 2 {
-		// remove the precending commas
-		answer = answer[2:]
+// prefilled is the prompt as the user sees it, with the earlier answer in place of
+// Default.
+func (m *MultiSelect) prefilled() MultiSelect {
+	prompt := *m
+	if m.previous != nil {
+		prompt.Default = m.previous
 	}
+	return prompt
+}
 
+// Cleanup removes the options section, and renders the ask like a normal question.
+// formatAnswer returns the answer the way it is shown once the question is answered.
+func (m *MultiSelect) formatAnswer(val interface{}) string {
+	labels := []string{}
+	for _, ans := range val.([]core.OptionAnswer) {
+		labels = append(labels, choiceLabel(m.Choices, ans))
+	}
+	return strings.Join(labels, ", ")
+}
+
+func (m *MultiSelect) Cleanup(config *PromptConfig, val interface{}) error {
 	// execute the output summary template with the answer
 	return m.Render(
 		MultiSelectQuestionTemplate,
@@ -303,7 +638,7 @@ func (m *MultiSelect) Cleanup(config *PromptConfig, val interface{}) error {
 			MultiSelect:   *m,
 			SelectedIndex: m.selectedIndex,
 			Checked:       m.checked,
-			Answer:        answer,
+			Answer:        m.formatAnswer(val),
 			ShowAnswer:    true,
 			Config:        config,
 		},
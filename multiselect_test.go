@@ -53,7 +53,7 @@ func TestMultiSelectRender(t *testing.T) {
 			},
 			strings.Join(
 				[]string{
-					fmt.Sprintf("%s Pick your words:  [Use arrows to move, enter to select, type to filter]", defaultIcons().Question.Text),
+					fmt.Sprintf("%s Pick your words:  [Use arrows to move, enter to select, right to check all, left to check none, ctrl+e to invert, tab to toggle a group, type to filter]", defaultIcons().Question.Text),
 					fmt.Sprintf("  %s  foo", defaultIcons().UnmarkedOption.Text),
 					fmt.Sprintf("  %s  bar", defaultIcons().MarkedOption.Text),
 					fmt.Sprintf("%s %s  baz", defaultIcons().SelectFocus.Text, defaultIcons().UnmarkedOption.Text),
@@ -81,7 +81,7 @@ func TestMultiSelectRender(t *testing.T) {
 			},
 			strings.Join(
 				[]string{
-					fmt.Sprintf("%s Pick your words:  [Use arrows to move, enter to select, type to filter, %s for more help]", defaultIcons().Question.Text, string(defaultPromptConfig().HelpInput)),
+					fmt.Sprintf("%s Pick your words:  [Use arrows to move, enter to select, right to check all, left to check none, ctrl+e to invert, tab to toggle a group, type to filter, %s for more help]", defaultIcons().Question.Text, string(defaultPromptConfig().HelpInput)),
 					fmt.Sprintf("  %s  foo", defaultIcons().UnmarkedOption.Text),
 					fmt.Sprintf("  %s  bar", defaultIcons().MarkedOption.Text),
 					fmt.Sprintf("%s %s  baz", defaultIcons().SelectFocus.Text, defaultIcons().UnmarkedOption.Text),
@@ -102,7 +102,7 @@ func TestMultiSelectRender(t *testing.T) {
 			strings.Join(
 				[]string{
 					fmt.Sprintf("%s This is helpful", defaultIcons().Help.Text),
-					fmt.Sprintf("%s Pick your words:  [Use arrows to move, enter to select, type to filter]", defaultIcons().Question.Text),
+					fmt.Sprintf("%s Pick your words:  [Use arrows to move, enter to select, right to check all, left to check none, ctrl+e to invert, tab to toggle a group, type to filter]", defaultIcons().Question.Text),
 					fmt.Sprintf("  %s  foo", defaultIcons().UnmarkedOption.Text),
 					fmt.Sprintf("  %s  bar", defaultIcons().MarkedOption.Text),
 					fmt.Sprintf("%s %s  baz", defaultIcons().SelectFocus.Text, defaultIcons().UnmarkedOption.Text),
@@ -121,7 +121,7 @@ func TestMultiSelectRender(t *testing.T) {
 			},
 			strings.Join(
 				[]string{
-					fmt.Sprintf("%s Pick your words:  [Use arrows to move, enter to select, type to filter]", defaultIcons().Question.Text),
+					fmt.Sprintf("%s Pick your words:  [Use arrows to move, enter to select, right to check all, left to check none, ctrl+e to invert, tab to toggle a group, type to filter]", defaultIcons().Question.Text),
 					fmt.Sprintf("%s %s  bar", defaultIcons().SelectFocus.Text, defaultIcons().UnmarkedOption.Text),
 					fmt.Sprintf("  %s  baz", defaultIcons().UnmarkedOption.Text),
 				},
@@ -163,7 +163,7 @@ func TestMultiSelectPrompt(t *testing.T) {
 				Options: []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
 			},
 			func(c *expect.Console) {
-				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, type to filter]")
+				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, right to check all, left to check none, ctrl+e to invert, tab to toggle a group, type to filter]")
 				// Select Monday.
 				c.Send(string(terminal.KeyArrowDown))
 				c.SendLine(" ")
@@ -179,7 +179,7 @@ func TestMultiSelectPrompt(t *testing.T) {
 				Default: []string{"Tuesday", "Thursday"},
 			},
 			func(c *expect.Console) {
-				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, type to filter]")
+				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, right to check all, left to check none, ctrl+e to invert, tab to toggle a group, type to filter]")
 				c.SendLine("")
 				c.ExpectEOF()
 			},
@@ -196,7 +196,7 @@ func TestMultiSelectPrompt(t *testing.T) {
 				Default: []int{2, 4},
 			},
 			func(c *expect.Console) {
-				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, type to filter]")
+				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, right to check all, left to check none, ctrl+e to invert, tab to toggle a group, type to filter]")
 				c.SendLine("")
 				c.ExpectEOF()
 			},
@@ -213,7 +213,7 @@ func TestMultiSelectPrompt(t *testing.T) {
 				Default: []string{"Tuesday", "Thursday"},
 			},
 			func(c *expect.Console) {
-				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, type to filter]")
+				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, right to check all, left to check none, ctrl+e to invert, tab to toggle a group, type to filter]")
 				// Deselect Tuesday.
 				c.Send(string(terminal.KeyArrowDown))
 				c.Send(string(terminal.KeyArrowDown))
@@ -230,7 +230,7 @@ func TestMultiSelectPrompt(t *testing.T) {
 				Help:    "Saturday is best",
 			},
 			func(c *expect.Console) {
-				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, type to filter, ? for more help]")
+				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, right to check all, left to check none, ctrl+e to invert, tab to toggle a group, type to filter, ? for more help]")
 				c.Send("?")
 				c.ExpectString("Saturday is best")
 				// Select Saturday
@@ -248,7 +248,7 @@ func TestMultiSelectPrompt(t *testing.T) {
 				PageSize: 1,
 			},
 			func(c *expect.Console) {
-				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, type to filter]")
+				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, right to check all, left to check none, ctrl+e to invert, tab to toggle a group, type to filter]")
 				// Select Monday.
 				c.Send(string(terminal.KeyArrowDown))
 				c.SendLine(" ")
@@ -264,7 +264,7 @@ func TestMultiSelectPrompt(t *testing.T) {
 				VimMode: true,
 			},
 			func(c *expect.Console) {
-				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, type to filter]")
+				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, right to check all, left to check none, ctrl+e to invert, tab to toggle a group, type to filter]")
 				// Select Tuesday.
 				c.Send("jj ")
 				// Select Thursday.
@@ -287,7 +287,7 @@ func TestMultiSelectPrompt(t *testing.T) {
 				Options: []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
 			},
 			func(c *expect.Console) {
-				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, type to filter]")
+				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, right to check all, left to check none, ctrl+e to invert, tab to toggle a group, type to filter]")
 				// Filter down to Tuesday.
 				c.Send("Tues")
 				// Select Tuesday.
@@ -304,7 +304,7 @@ func TestMultiSelectPrompt(t *testing.T) {
 				Options: []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
 			},
 			func(c *expect.Console) {
-				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, type to filter]")
+				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, right to check all, left to check none, ctrl+e to invert, tab to toggle a group, type to filter]")
 				// Filter down to Tuesday.
 				c.Send("tues")
 				// Select Tuesday.
@@ -341,7 +341,7 @@ func TestMultiSelectPrompt(t *testing.T) {
 				Options: []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
 			},
 			func(c *expect.Console) {
-				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, type to filter]")
+				c.ExpectString("What days do you prefer:  [Use arrows to move, enter to select, right to check all, left to check none, ctrl+e to invert, tab to toggle a group, type to filter]")
 				// Filter down to Tuesday.
 				c.Send("Tues")
 				// Select Tuesday.
@@ -355,6 +355,130 @@ func TestMultiSelectPrompt(t *testing.T) {
 			},
 			[]core.OptionAnswer{},
 		},
+		{
+			"choices can't check disabled options",
+			&MultiSelect{
+				Message: "Which regions?",
+				Choices: []Choice{
+					{Label: "US East", Value: "us-east-1"},
+					{Label: "AP South", Value: "ap-south-1", Disabled: true},
+					{Label: "EU West", Value: "eu-west-1", Description: "Ireland"},
+				},
+			},
+			func(c *expect.Console) {
+				c.ExpectString("Which regions?")
+				// check the first region, then try the disabled one by filtering to it
+				c.Send(" ")
+				c.Send("AP")
+				c.Send(" ")
+				c.Send(string(terminal.KeyDeleteLine))
+				c.Send(string(terminal.KeyArrowDown))
+				c.ExpectString("Ireland")
+				c.Send(" ")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			[]core.OptionAnswer{{Value: "us-east-1", Index: 0}, {Value: "eu-west-1", Index: 2}},
+		},
+		{
+			"tab toggles a group",
+			&MultiSelect{
+				Message: "Which regions?",
+				Choices: []Choice{
+					{Label: "US East", Group: "Cloud regions"},
+					{Label: "AP South", Group: "Cloud regions", Disabled: true},
+					{Label: "EU West", Group: "Cloud regions"},
+					{Label: "Frankfurt DC", Group: "On-prem"},
+					{Label: "Dublin DC", Group: "On-prem"},
+				},
+				Default: []string{"US East", "Dublin DC"},
+			},
+			func(c *expect.Console) {
+				c.ExpectString("Which regions?")
+				// check the rest of the cloud regions
+				c.Send(string(terminal.KeyTab))
+				// then check and uncheck the whole on-prem group
+				c.Send(string(terminal.KeyArrowDown))
+				c.Send(string(terminal.KeyArrowDown))
+				c.Send(string(terminal.KeyTab))
+				c.Send(string(terminal.KeyTab))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			[]core.OptionAnswer{
+				{Value: "US East", Index: 0},
+				{Value: "EU West", Index: 2},
+			},
+		},
+		{
+			"check all, none and invert",
+			&MultiSelect{
+				Message: "What days do you prefer:",
+				Options: []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+			},
+			func(c *expect.Console) {
+				c.ExpectString("What days do you prefer:")
+				// check the days with a t in them
+				c.Send("t")
+				// wait for the filter so the keys below reach the prompt and not the terminal
+				c.ExpectString("What days do you prefer: t")
+				c.Send(string(terminal.KeyArrowRight))
+				// then flip every day
+				c.Send(string(terminal.KeyDeleteWord))
+				c.Send(string(keyInvert))
+				// and uncheck friday again
+				c.Send("f")
+				c.Send(string(terminal.KeyArrowLeft))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			[]core.OptionAnswer{
+				{Value: "Monday", Index: 0},
+				{Value: "Wednesday", Index: 2},
+			},
+		},
+		{
+			"max selected",
+			&MultiSelect{
+				Message:     "What days do you prefer:",
+				Options:     []string{"Monday", "Tuesday", "Wednesday"},
+				MaxSelected: 2,
+			},
+			func(c *expect.Console) {
+				c.ExpectString("What days do you prefer:")
+				c.Send(" ")
+				c.Send(string(terminal.KeyArrowDown))
+				c.Send(" ")
+				c.Send(string(terminal.KeyArrowDown))
+				c.Send(" ")
+				c.ExpectString("You can select at most 2 options")
+				// checking everything is refused too
+				c.Send(string(terminal.KeyArrowRight))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			[]core.OptionAnswer{
+				{Value: "Monday", Index: 0},
+				{Value: "Tuesday", Index: 1},
+			},
+		},
+		{
+			"min selected",
+			&MultiSelect{
+				Message:     "What days do you prefer:",
+				Options:     []string{"Monday", "Tuesday", "Wednesday"},
+				MinSelected: 1,
+			},
+			func(c *expect.Console) {
+				c.ExpectString("What days do you prefer:")
+				c.SendLine("")
+				c.ExpectString("Select at least 1 option")
+				c.Send(" ")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			[]core.OptionAnswer{{Value: "Monday", Index: 0}},
+		},
 	}
 
 	for _, test := range tests {
@@ -363,3 +487,96 @@ func TestMultiSelectPrompt(t *testing.T) {
 		})
 	}
 }
+
+func TestAskOne_multiSelectLoad(t *testing.T) {
+	answer := []core.OptionAnswer{}
+	RunTest(t, func(c *expect.Console) {
+		c.ExpectString("green")
+		c.Send(" ")
+		// options checked under another filter stay checked
+		c.Send("gr")
+		c.ExpectString("grey")
+		c.Send(string(terminal.KeyArrowDown))
+		c.Send(" ")
+		c.SendLine("")
+		c.ExpectEOF()
+	}, func(stdio terminal.Stdio) error {
+		return AskOne(
+			&MultiSelect{Message: "Which colors?", Load: loadColors, Default: []string{"blue"}},
+			&answer,
+			WithStdio(stdio.In, stdio.Out, stdio.Err),
+		)
+	})
+	assert.Equal(t, []core.OptionAnswer{
+		{Value: "blue", Index: 0},
+		{Value: "red", Index: 1},
+		{Value: "grey", Index: 3},
+	}, answer)
+}
+
+func TestMultiSelectDefaultAnswer(t *testing.T) {
+	tests := []struct {
+		name     string
+		prompt   *MultiSelect
+		expected []core.OptionAnswer
+	}{
+		{
+			"no default",
+			&MultiSelect{Options: []string{"Monday", "Tuesday", "Wednesday"}},
+			[]core.OptionAnswer{},
+		},
+		{
+			"default values",
+			&MultiSelect{Options: []string{"Monday", "Tuesday", "Wednesday"}, Default: []string{"Wednesday", "Monday"}},
+			[]core.OptionAnswer{{Index: 0, Value: "Monday"}, {Index: 2, Value: "Wednesday"}},
+		},
+		{
+			"default indices",
+			&MultiSelect{Options: []string{"Monday", "Tuesday", "Wednesday"}, Default: []int{1}},
+			[]core.OptionAnswer{{Index: 1, Value: "Tuesday"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			answer, err := test.prompt.DefaultAnswer()
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, answer)
+		})
+	}
+}
+
+func TestMultiSelectDefaultAnswer_limits(t *testing.T) {
+	days := []string{"Monday", "Tuesday", "Wednesday"}
+
+	// there is nothing to fall back on without a default
+	_, err := (&MultiSelect{Options: days, MinSelected: 1}).DefaultAnswer()
+	assert.Equal(t, ErrNoDefault, err)
+
+	// and a default has to stay within the limits like any other answer
+	_, err = (&MultiSelect{Options: days, MinSelected: 2, Default: []int{0}}).DefaultAnswer()
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "select at least 2 options", err.Error())
+	}
+	_, err = (&MultiSelect{Options: days, MaxSelected: 1, Default: []int{0, 1}}).DefaultAnswer()
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "select at most 1 option", err.Error())
+	}
+}
+
+func TestMultiSelectConvertAnswer_limits(t *testing.T) {
+	prompt := &MultiSelect{
+		Options:     []string{"Monday", "Tuesday", "Wednesday"},
+		MinSelected: 1,
+		MaxSelected: 2,
+	}
+
+	answer, err := prompt.ConvertAnswer([]string{"Tuesday"})
+	assert.Nil(t, err)
+	assert.Equal(t, []core.OptionAnswer{{Value: "Tuesday", Index: 1}}, answer)
+
+	_, err = prompt.ConvertAnswer([]string{})
+	assert.EqualError(t, err, "select at least 1 option")
+	_, err = prompt.ConvertAnswer([]int{0, 1, 2})
+	assert.EqualError(t, err, "select at most 2 options")
+}
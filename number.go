@@ -0,0 +1,314 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+/*
+Number is a prompt that only accepts numbers. The up and down arrows change the answer
+by Step, and the answer can't be below Min or above Max when they are set. Default is
+left out when the user has to type a number. Response type is an int64, or a float64
+if Float is set.
+
+	port := 0
+	dflt, min, max := 8080.0, 1.0, 65535.0
+	prompt := &survey.Number{
+		Message: "Which port should we listen on?",
+		Default: &dflt,
+		Min:     &min,
+		Max:     &max,
+	}
+	survey.AskOne(prompt, &port)
+*/
+type Number struct {
+	Renderer
+	Message     string
+	Default     *float64
+	Min         *float64
+	Max         *float64
+	Step        float64
+	Float       bool
+	Help        string
+	input       string
+	showingHelp bool
+	// the answer given last time, when going back to the question
+	previous *float64
+}
+
+// NumberTemplateData is the data available to the templates when processing
+type NumberTemplateData struct {
+	Number
+	Input      string
+	Answer     string
+	ShowAnswer bool
+	ShowHelp   bool
+	Config     *PromptConfig
+}
+
+// Templates with Color formatting. See Documentation: https://github.com/mgutz/ansi#style-format
+var NumberQuestionTemplate = `
+{{- if .ShowHelp }}{{- color .Config.Icons.Help.Format }}{{ .Config.Icons.Help.Text }} {{ .Help }}{{color "reset"}}{{"\n"}}{{end}}
+{{- color .Config.Icons.Question.Format }}{{ .Config.Icons.Question.Text }} {{color "reset"}}
+{{- color "default+hb"}}{{ .Message }} {{color "reset"}}
+{{- if .ShowAnswer}}
+  {{- color "cyan"}}{{.Answer}}{{color "reset"}}{{"\n"}}
+{{- else }}
+  {{- if and .Help (not .ShowHelp)}}{{color "cyan"}}[{{ .Config.HelpInput }} for help]{{color "reset"}} {{end}}
+  {{- if or .Min .Max .Step}}{{color "white"}}[
+    {{- if and .Min .Max}}{{ .Min }} to {{ .Max }}
+    {{- else if .Min}}at least {{ .Min }}
+    {{- else if .Max}}at most {{ .Max }}{{end}}
+    {{- if .Step}}{{if or .Min .Max}}, {{end}}step {{ .Step }}{{end}}] {{color "reset"}}
+  {{- end}}
+  {{- if .Default}}{{color "white"}}({{ .Default }}) {{color "reset"}}{{end}}
+  {{- .Input}}
+{{- end}}`
+
+func (n *Number) Prompt(config *PromptConfig) (interface{}, error) {
+	return n.prompt("", config)
+}
+
+// PromptAgain asks for the number again, starting from the answer that didn't pass
+// validation so the user can change it.
+func (n *Number) PromptAgain(config *PromptConfig, invalid interface{}, err error) (interface{}, error) {
+	return n.prompt(formatNumber(invalid), config)
+}
+
+func (n *Number) prompt(input string, config *PromptConfig) (interface{}, error) {
+	n.input = input
+	n.showingHelp = false
+
+	// ask the question
+	err := n.render(config)
+	if err != nil {
+		return nil, err
+	}
+
+	rr := n.NewRuneReader()
+	rr.SetTermMode()
+	defer rr.RestoreTermMode()
+
+	// start waiting for input
+	for {
+		r, _, err := rr.ReadRune()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case r == terminal.KeyInterrupt:
+			return nil, terminal.InterruptErr
+		case r == terminal.SpecialKeyShiftTab:
+			return nil, terminal.GoBackErr
+		case r == terminal.KeyEnter || r == '\n' || r == terminal.KeyEndTransmission:
+			ans, invalid := n.answer()
+			// if the user typed a number we can use we're done
+			if invalid == nil {
+				return ans, nil
+			}
+			// otherwise tell them what's wrong and let them fix it
+			if err := n.Error(config, invalid); err != nil {
+				return nil, err
+			}
+		case r == terminal.KeyArrowUp:
+			n.step(1)
+		case r == terminal.KeyArrowDown:
+			n.step(-1)
+		case r == terminal.KeyDelete || r == terminal.KeyBackspace:
+			// remove the last character the user typed
+			if input := []rune(n.input); len(input) > 0 {
+				n.input = string(input[:len(input)-1])
+			}
+		case r == terminal.KeyDeleteWord || r == terminal.KeyDeleteLine:
+			n.input = ""
+		case string(r) == config.HelpInput && n.Help != "":
+			n.showingHelp = true
+		// only let the user type things that make up a number
+		case r >= '0' && r <= '9',
+			r == '-' && n.input == "",
+			r == '.' && n.Float && !strings.ContainsRune(n.input, '.'):
+			n.input += string(r)
+		}
+
+		err = n.render(config)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (n *Number) render(config *PromptConfig) error {
+	return n.Render(
+		NumberQuestionTemplate,
+		NumberTemplateData{
+			Number:   n.prefilled(),
+			Input:    n.input,
+			ShowHelp: n.showingHelp,
+			Config:   config,
+		},
+	)
+}
+
+// step moves the answer up or down by Step, staying within range.
+func (n *Number) step(direction float64) {
+	value := 0.0
+	if dflt := n.prefilled().Default; dflt != nil {
+		value = *dflt
+	}
+	if parsed, err := strconv.ParseFloat(n.input, 64); err == nil {
+		value = parsed
+	}
+
+	step := n.Step
+	if step == 0 {
+		step = 1
+	}
+	value += direction * step
+	if n.Min != nil {
+		value = math.Max(*n.Min, value)
+	}
+	if n.Max != nil {
+		value = math.Min(*n.Max, value)
+	}
+
+	// don't let the steps add up to more decimals than they had
+	precision := decimals(step)
+	if typed := strings.IndexRune(n.input, '.'); typed >= 0 && len(n.input)-typed-1 > precision {
+		precision = len(n.input) - typed - 1
+	}
+	n.input = strconv.FormatFloat(value, 'f', precision, 64)
+}
+
+// answer parses what the user typed, falling back to the default if they didn't type anything.
+func (n *Number) answer() (interface{}, error) {
+	if n.input == "" {
+		dflt := n.prefilled().Default
+		if dflt == nil {
+			return nil, errors.New("type a number")
+		}
+		return n.checked(*dflt)
+	}
+
+	value, err := strconv.ParseFloat(n.input, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a number", n.input)
+	}
+	return n.checked(value)
+}
+
+// checked makes sure the value is within range and returns it as the response type.
+func (n *Number) checked(value float64) (interface{}, error) {
+	if n.Min != nil && n.Max != nil && (value < *n.Min || value > *n.Max) {
+		return nil, fmt.Errorf("%v is not between %v and %v", formatNumber(value), formatNumber(*n.Min), formatNumber(*n.Max))
+	}
+	if n.Min != nil && value < *n.Min {
+		return nil, fmt.Errorf("%v is less than %v", formatNumber(value), formatNumber(*n.Min))
+	}
+	if n.Max != nil && value > *n.Max {
+		return nil, fmt.Errorf("%v is more than %v", formatNumber(value), formatNumber(*n.Max))
+	}
+	if n.Float {
+		return value, nil
+	}
+	if value != math.Trunc(value) {
+		return nil, fmt.Errorf("%v is not a whole number", formatNumber(value))
+	}
+	return int64(value), nil
+}
+
+// DefaultAnswer returns the answer the user would get by just pressing enter.
+func (n *Number) DefaultAnswer() (interface{}, error) {
+	dflt := n.prefilled().Default
+	if dflt == nil {
+		return nil, ErrNoDefault
+	}
+	return n.checked(*dflt)
+}
+
+// ConvertAnswer turns a supplied answer into a number. Besides numbers, the answer
+// can be the text the user would type.
+func (n *Number) ConvertAnswer(value interface{}) (interface{}, error) {
+	switch val := value.(type) {
+	case string:
+		parsed, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", val)
+		}
+		return n.checked(parsed)
+	case float64:
+		return n.checked(val)
+	case float32:
+		return n.checked(float64(val))
+	case int:
+		return n.checked(float64(val))
+	case int64:
+		return n.checked(float64(val))
+	}
+	return nil, fmt.Errorf("cannot use a %T as a number", value)
+}
+
+// prefill makes an earlier answer the default when going back to the question, or
+// goes back to Default when there is none.
+func (n *Number) prefill(ans interface{}) {
+	n.previous = nil
+	switch val := ans.(type) {
+	case int64:
+		previous := float64(val)
+		n.previous = &previous
+	case float64:
+		n.previous = &val
+	}
+}
+
+// prefilled is the prompt as the user sees it, with the earlier answer in place of
+// Default.
+func (n *Number) prefilled() Number {
+	prompt := *n
+	if n.previous != nil {
+		prompt.Default = n.previous
+	}
+	return prompt
+}
+
+// formatAnswer returns the answer the way it is shown once the question is answered.
+func (n *Number) formatAnswer(val interface{}) string {
+	return formatNumber(val)
+}
+
+func (n *Number) Cleanup(config *PromptConfig, val interface{}) error {
+	return n.Render(
+		NumberQuestionTemplate,
+		NumberTemplateData{
+			Number:     *n,
+			Answer:     n.formatAnswer(val),
+			ShowAnswer: true,
+			Config:     config,
+		},
+	)
+}
+
+// formatNumber formats an answer to a Number the way the user would type it.
+func formatNumber(val interface{}) string {
+	switch num := val.(type) {
+	case int64:
+		return strconv.FormatInt(num, 10)
+	case float64:
+		return strconv.FormatFloat(num, 'f', -1, 64)
+	}
+	return fmt.Sprint(val)
+}
+
+// decimals returns the number of digits needed after the decimal point to show the value.
+func decimals(value float64) int {
+	text := strconv.FormatFloat(value, 'f', -1, 64)
+	if dot := strings.IndexRune(text, '.'); dot >= 0 {
+		return len(text) - dot - 1
+	}
+	return 0
+}
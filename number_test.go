@@ -0,0 +1,287 @@
+package survey
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	expect "github.com/Netflix/go-expect"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	// disable color output for all prompts to simplify testing
+	core.DisableColor = true
+}
+
+func TestNumberRender(t *testing.T) {
+
+	tests := []struct {
+		title    string
+		prompt   Number
+		data     NumberTemplateData
+		expected string
+	}{
+		{
+			"Test Number question output",
+			Number{Message: "How many?"},
+			NumberTemplateData{},
+			fmt.Sprintf("%s How many? ", defaultIcons().Question.Text),
+		},
+		{
+			"Test Number question output with range and default",
+			Number{Message: "Which port?", Min: num(1), Max: num(65535), Default: num(8080)},
+			NumberTemplateData{},
+			fmt.Sprintf("%s Which port? [1 to 65535] (8080) ", defaultIcons().Question.Text),
+		},
+		{
+			"Test Number question output with range and step",
+			Number{Message: "How much?", Min: num(0.5), Max: num(2), Step: 0.25},
+			NumberTemplateData{Input: "1.2"},
+			fmt.Sprintf("%s How much? [0.5 to 2, step 0.25] 1.2", defaultIcons().Question.Text),
+		},
+		{
+			"Test Number question output with a minimum",
+			Number{Message: "How many?", Min: num(1)},
+			NumberTemplateData{},
+			fmt.Sprintf("%s How many? [at least 1] ", defaultIcons().Question.Text),
+		},
+		{
+			"Test Number question output with a maximum and step",
+			Number{Message: "How many?", Max: num(0), Step: 5},
+			NumberTemplateData{},
+			fmt.Sprintf("%s How many? [at most 0, step 5] ", defaultIcons().Question.Text),
+		},
+		{
+			"Test Number question output with step",
+			Number{Message: "How many?", Step: 5},
+			NumberTemplateData{},
+			fmt.Sprintf("%s How many? [step 5] ", defaultIcons().Question.Text),
+		},
+		{
+			"Test Number question output with a default of 0",
+			Number{Message: "How many?", Default: num(0)},
+			NumberTemplateData{},
+			fmt.Sprintf("%s How many? (0) ", defaultIcons().Question.Text),
+		},
+		{
+			"Test Number answer output",
+			Number{Message: "How many?", Min: num(1), Max: num(10)},
+			NumberTemplateData{Answer: "3", ShowAnswer: true},
+			fmt.Sprintf("%s How many? 3\n", defaultIcons().Question.Text),
+		},
+		{
+			"Test Number question output with help hidden",
+			Number{Message: "How many?", Help: "This is helpful"},
+			NumberTemplateData{},
+			fmt.Sprintf("%s How many? [%s for help] ", defaultIcons().Question.Text, string(defaultPromptConfig().HelpInput)),
+		},
+	}
+
+	for _, test := range tests {
+		r, w, err := os.Pipe()
+		assert.Nil(t, err, test.title)
+
+		test.prompt.WithStdio(terminal.Stdio{Out: w})
+		test.data.Number = test.prompt
+
+		// set the runtime config
+		test.data.Config = defaultPromptConfig()
+
+		err = test.prompt.Render(
+			NumberQuestionTemplate,
+			test.data,
+		)
+		assert.Nil(t, err, test.title)
+
+		w.Close()
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+
+		assert.Contains(t, buf.String(), test.expected, test.title)
+	}
+}
+
+func TestNumberPrompt(t *testing.T) {
+	tests := []PromptTest{
+		{
+			"Test Number prompt interaction",
+			&Number{Message: "How many?"},
+			func(c *expect.Console) {
+				c.ExpectString("How many?")
+				c.SendLine("42")
+				c.ExpectEOF()
+			},
+			int64(42),
+		},
+		{
+			"Test Number prompt interaction with default",
+			&Number{Message: "Which port?", Default: num(8080)},
+			func(c *expect.Console) {
+				c.ExpectString("Which port?")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			int64(8080),
+		},
+		{
+			"Test Number prompt ignores anything that isn't a number",
+			&Number{Message: "How many?"},
+			func(c *expect.Console) {
+				c.ExpectString("How many?")
+				c.SendLine("-1a2.5")
+				c.ExpectEOF()
+			},
+			int64(-125),
+		},
+		{
+			"Test Number prompt interaction with float",
+			&Number{Message: "How much?", Float: true},
+			func(c *expect.Console) {
+				c.ExpectString("How much?")
+				c.SendLine("2.5")
+				c.ExpectEOF()
+			},
+			2.5,
+		},
+		{
+			"Test Number prompt interaction with arrows",
+			&Number{Message: "How many?", Default: num(10), Step: 5},
+			func(c *expect.Console) {
+				c.ExpectString("How many?")
+				// move up twice from the default and back down once
+				c.Send(string(terminal.KeyArrowUp))
+				c.Send(string(terminal.KeyArrowUp))
+				c.Send(string(terminal.KeyArrowDown))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			int64(15),
+		},
+		{
+			"Test Number prompt arrows stay in range",
+			&Number{Message: "How much?", Min: num(0), Max: num(0.5), Step: 0.2, Float: true},
+			func(c *expect.Console) {
+				c.ExpectString("How much?")
+				c.Send(string(terminal.KeyArrowUp))
+				c.Send(string(terminal.KeyArrowUp))
+				c.Send(string(terminal.KeyArrowUp))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			0.5,
+		},
+		{
+			"Test Number prompt arrows only stop at the bounds that are set",
+			&Number{Message: "How many?", Min: num(1), Default: num(1)},
+			func(c *expect.Console) {
+				c.ExpectString("How many?")
+				c.Send(string(terminal.KeyArrowDown))
+				c.Send(string(terminal.KeyArrowUp))
+				c.Send(string(terminal.KeyArrowUp))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			int64(3),
+		},
+		{
+			"Test Number prompt asks again when out of range",
+			&Number{Message: "How many?", Min: num(1), Max: num(10)},
+			func(c *expect.Console) {
+				c.ExpectString("How many?")
+				c.SendLine("11")
+				c.ExpectString("11 is not between 1 and 10")
+				c.Send(string(terminal.KeyBackspace))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			int64(1),
+		},
+		{
+			"Test Number prompt interaction with help",
+			&Number{Message: "How many?", Help: "It's a number"},
+			func(c *expect.Console) {
+				c.ExpectString("How many?")
+				c.Send("?")
+				c.ExpectString("It's a number")
+				c.SendLine("7")
+				c.ExpectEOF()
+			},
+			int64(7),
+		},
+		{
+			"Test Number prompt asks for a number without a default",
+			&Number{Message: "How many?"},
+			func(c *expect.Console) {
+				c.ExpectString("How many?")
+				c.SendLine("")
+				c.ExpectString("type a number")
+				c.SendLine("0")
+				c.ExpectEOF()
+			},
+			int64(0),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			RunPromptTest(t, test)
+		})
+	}
+}
+
+func TestNumberDefaultAnswer(t *testing.T) {
+	_, err := (&Number{}).DefaultAnswer()
+	assert.Equal(t, ErrNoDefault, err)
+
+	answer, err := (&Number{Default: num(0)}).DefaultAnswer()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), answer)
+}
+
+func TestNumberConvertAnswer(t *testing.T) {
+	prompt := &Number{Min: num(1), Max: num(10)}
+
+	tests := []struct {
+		value    interface{}
+		expected interface{}
+	}{
+		{"3", int64(3)},
+		{3, int64(3)},
+		{float64(3), int64(3)},
+	}
+	for _, test := range tests {
+		answer, err := prompt.ConvertAnswer(test.value)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, answer)
+	}
+
+	for _, value := range []interface{}{"three", "11", 2.5, true} {
+		_, err := prompt.ConvertAnswer(value)
+		assert.NotNil(t, err, "converting %v", value)
+	}
+
+	// a single bound leaves the other side open
+	atLeastOne := &Number{Min: num(1)}
+	answer, err := atLeastOne.ConvertAnswer(1000)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1000), answer)
+	_, err = atLeastOne.ConvertAnswer(0)
+	assert.EqualError(t, err, "0 is less than 1")
+
+	negative := &Number{Max: num(0)}
+	answer, err = negative.ConvertAnswer(-5)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-5), answer)
+	_, err = negative.ConvertAnswer(1)
+	assert.EqualError(t, err, "1 is more than 0")
+}
+
+// num returns a pointer to a number, for the default and bounds of a Number.
+func num(value float64) *float64 {
+	return &value
+}
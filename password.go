@@ -1,9 +1,11 @@
 package survey
 
 import (
-	"fmt"
+	"errors"
+	"math"
+	"strings"
+	"unicode"
 
-	"github.com/AlecAivazis/survey/v2/core"
 	"github.com/AlecAivazis/survey/v2/terminal"
 )
 
@@ -14,36 +16,81 @@ type is a string.
 	password := ""
 	prompt := &survey.Password{ Message: "Please type your password" }
 	survey.AskOne(prompt, &password)
+
+Confirm asks for the password twice, Strength shows how strong it is as the user types
+and Reveal lets them show what they typed by pressing tab.
+
+	prompt := &survey.Password{
+		Message:  "Choose a password",
+		Confirm:  true,
+		Strength: survey.EntropyStrength,
+		Reveal:   true,
+	}
 */
 type Password struct {
 	Renderer
-	Message string
-	Help    string
+	Message        string
+	Help           string
+	Confirm        bool
+	ConfirmMessage string
+	Strength       func(password string) PasswordStrength
+	Reveal         bool
+	input          []rune
+	confirming     bool
+	revealed       bool
+	showingHelp    bool
 }
 
 type PasswordTemplateData struct {
 	Password
-	ShowHelp bool
-	Config   *PromptConfig
+	Input      string
+	Rating     *PasswordStrength
+	Meter      string
+	Confirming bool
+	Revealed   bool
+	Answer     string
+	ShowAnswer bool
+	ShowHelp   bool
+	Config     *PromptConfig
 }
 
 // PasswordQuestionTemplate is a template with color formatting. See Documentation: https://github.com/mgutz/ansi#style-format
 var PasswordQuestionTemplate = `
 {{- if .ShowHelp }}{{- color .Config.Icons.Help.Format }}{{ .Config.Icons.Help.Text }} {{ .Help }}{{color "reset"}}{{"\n"}}{{end}}
 {{- color .Config.Icons.Question.Format }}{{ .Config.Icons.Question.Text }} {{color "reset"}}
-{{- color "default+hb"}}{{ .Message }} {{color "reset"}}
-{{- if and .Help (not .ShowHelp)}}{{color "cyan"}}[{{ .Config.HelpInput }} for help]{{color "reset"}} {{end}}`
+{{- color "default+hb"}}{{ if .Confirming }}{{ or .ConfirmMessage "Type it again:" }}{{else}}{{ .Message }}{{end}} {{color "reset"}}
+{{- if .ShowAnswer}}
+  {{- color "cyan"}}{{.Answer}}{{color "reset"}}{{"\n"}}
+{{- else }}
+  {{- if and .Help (not .ShowHelp)}}{{color "cyan"}}[{{ .Config.HelpInput }} for help]{{color "reset"}} {{end}}
+  {{- if .Reveal}}{{color "cyan"}}[tab to {{if .Revealed}}hide{{else}}show{{end}}]{{color "reset"}} {{end}}
+  {{- .Input}}
+  {{- with .Rating}}{{"\n"}}
+    {{- if lt .Score 2}}{{color "red"}}{{else if lt .Score 3}}{{color "yellow"}}{{else}}{{color "green"}}{{end}}
+    {{- "  "}}{{$.Meter}} {{.Label}}{{color "reset"}}
+  {{- end}}
+{{- end}}`
 
-func (p *Password) Prompt(config *PromptConfig) (line interface{}, err error) {
-	// render the question template
-	out, err := core.RunTemplate(
-		PasswordQuestionTemplate,
-		PasswordTemplateData{
-			Password: *p,
-			Config:   config,
-		},
-	)
-	fmt.Fprint(terminal.NewAnsiStdout(p.Stdio().Out), out)
+// errPasswordMismatch is shown when the password isn't typed the same way twice.
+var errPasswordMismatch = errors.New("the passwords don't match")
+
+func (p *Password) Prompt(config *PromptConfig) (interface{}, error) {
+	p.input = nil
+	p.confirming = false
+	p.revealed = false
+	p.showingHelp = false
+	// the password typed the first time, to compare against when confirming it
+	first := ""
+
+	// the cursor would sit after the meter instead of the password
+	if p.Strength != nil {
+		cursor := p.NewCursor()
+		cursor.Hide()       // hide the cursor
+		defer cursor.Show() // show the cursor when we're done
+	}
+
+	// ask the question
+	err := p.render(config)
 	if err != nil {
 		return "", err
 	}
@@ -52,43 +99,272 @@ func (p *Password) Prompt(config *PromptConfig) (line interface{}, err error) {
 	rr.SetTermMode()
 	defer rr.RestoreTermMode()
 
-	// no help msg?  Just return any response
-	if p.Help == "" {
-		line, err := rr.ReadLine('*')
-		return string(line), err
+	// start waiting for input
+	for {
+		line, err := p.readLine(rr, config)
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		// the user asked for help
+		case string(line) == config.HelpInput && p.Help != "" && !p.confirming:
+			p.showingHelp = true
+		// they don't have to type it again
+		case !p.Confirm:
+			return string(line), nil
+		// ask for it again to make sure they typed what they meant to
+		case !p.confirming:
+			first = string(line)
+			p.confirming = true
+		case string(line) == first:
+			return string(line), nil
+		// otherwise tell them and start over
+		default:
+			first = ""
+			p.confirming = false
+			if err := p.Error(config, errPasswordMismatch); err != nil {
+				return "", err
+			}
+		}
+
+		err = p.render(config)
+		if err != nil {
+			return "", err
+		}
 	}
+}
 
-	cursor := p.NewCursor()
+// errReveal stops reading a line so the password can be shown or hidden
+var errReveal = errors.New("reveal")
 
-	// process answers looking for help prompt answer
+// readLine reads the password up to the enter key. Unless the meter has to be drawn, the
+// line is read the way Input reads it, so it can be edited anywhere.
+func (p *Password) readLine(rr *terminal.RuneReader, config *PromptConfig) ([]rune, error) {
+	if p.Strength != nil {
+		return p.readRunes(rr, config)
+	}
+
+	line := []rune{}
 	for {
-		line, err := rr.ReadLine('*')
+		mask := '*'
+		if p.revealed {
+			mask = 0
+		}
+		var err error
+		line, err = rr.ReadLineWithDefault(mask, line, p.onRune)
+		if err != errReveal {
+			if err == nil {
+				// the terminal echoed the \n so we need to jump back up one row
+				p.NewCursor().PreviousLine(1)
+			}
+			return line, err
+		}
+
+		// print the line again the other way
+		p.revealed = !p.revealed
+		err = p.render(config)
 		if err != nil {
-			return string(line), err
+			return nil, err
 		}
+	}
+}
+
+// onRune stops reading the line when the user wants to show or hide the password.
+func (p *Password) onRune(key rune, line []rune) ([]rune, bool, error) {
+	if key == terminal.KeyTab && p.Reveal {
+		return line, true, errReveal
+	}
+	return line, false, nil
+}
 
-		if string(line) == config.HelpInput {
-			// terminal will echo the \n so we need to jump back up one row
-			cursor.PreviousLine(1)
-
-			err = p.Render(
-				PasswordQuestionTemplate,
-				PasswordTemplateData{
-					Password: *p,
-					ShowHelp: true,
-					Config:   config,
-				},
-			)
+// readRunes reads the password one key at a time, drawing the meter again after every
+// change, which ReadLine has no way to do.
+func (p *Password) readRunes(rr *terminal.RuneReader, config *PromptConfig) ([]rune, error) {
+	for {
+		r, _, err := rr.ReadRune()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case r == terminal.KeyInterrupt:
+			return nil, terminal.InterruptErr
+		case r == terminal.SpecialKeyShiftTab:
+			return nil, terminal.GoBackErr
+		case r == terminal.KeyEnter || r == '\n' || r == terminal.KeyEndTransmission:
+			line := p.input
+			p.input = nil
+			return line, nil
+		case r == terminal.KeyTab && p.Reveal:
+			p.revealed = !p.revealed
+		case r == terminal.KeyDelete || r == terminal.KeyBackspace:
+			// remove the last character the user typed
+			if len(p.input) > 0 {
+				p.input = p.input[:len(p.input)-1]
+			}
+		case r == terminal.KeyDeleteWord || r == terminal.KeyDeleteLine:
+			p.input = nil
+		case !unicode.IsControl(r):
+			p.input = append(p.input, r)
+		}
+
+		// wait until everything that was pasted is in before drawing the prompt
+		if rr.Buffered() == 0 {
+			err = p.render(config)
 			if err != nil {
-				return "", err
+				return nil, err
 			}
-			continue
 		}
-		return string(line), err
 	}
 }
 
+func (p *Password) render(config *PromptConfig) error {
+	data := PasswordTemplateData{
+		Password:   *p,
+		Input:      strings.Repeat("*", len(p.input)),
+		Confirming: p.confirming,
+		Revealed:   p.revealed,
+		ShowHelp:   p.showingHelp,
+		Config:     config,
+	}
+	if p.revealed {
+		data.Input = string(p.input)
+	}
+	// rate the password while it is being picked, not while it is confirmed
+	if p.Strength != nil && len(p.input) > 0 && !p.confirming {
+		rating := p.Strength(string(p.input))
+		data.Rating = &rating
+		data.Meter = strengthMeter(rating.Score)
+	}
+
+	return p.Render(PasswordQuestionTemplate, data)
+}
+
+// hiddenPassword is shown in place of a password once it has been typed.
+const hiddenPassword = "********"
+
+// formatAnswer hides the answer the way it is hidden once the question is answered.
+func (p *Password) formatAnswer(val interface{}) string {
+	return hiddenPassword
+}
+
 // Cleanup hides the string with a fixed number of characters.
-func (prompt *Password) Cleanup(config *PromptConfig, val interface{}) error {
-	return nil
+func (p *Password) Cleanup(config *PromptConfig, val interface{}) error {
+	return p.Render(
+		PasswordQuestionTemplate,
+		PasswordTemplateData{
+			Password:   *p,
+			Answer:     p.formatAnswer(val),
+			ShowAnswer: true,
+			Config:     config,
+		},
+	)
+}
+
+// PasswordStrength rates how hard a password is to guess, from a Score of 0 for the
+// weakest passwords up to 4 for the strongest, with a Label to show next to the meter.
+type PasswordStrength struct {
+	Score int
+	Label string
+}
+
+// the labels EntropyStrength gives each score
+var strengthLabels = []string{"very weak", "weak", "fair", "strong", "very strong"}
+
+// strengthMeter draws a bar that fills up as the score goes up.
+func strengthMeter(score int) string {
+	if score < 0 {
+		score = 0
+	}
+	if score > len(strengthLabels)-1 {
+		score = len(strengthLabels) - 1
+	}
+	return "[" + strings.Repeat("#", score) + strings.Repeat("-", len(strengthLabels)-1-score) + "]"
+}
+
+/*
+EntropyStrength rates a password by its entropy, which is how many guesses it would take
+to find it by trying every password of the same length made from the same kinds of
+characters. Mixing lowercase, uppercase, digits and symbols counts as much as making it
+longer.
+
+	prompt := &survey.Password{Message: "Choose a password", Strength: survey.EntropyStrength}
+*/
+func EntropyStrength(password string) PasswordStrength {
+	// how many characters each character could have been
+	var lower, upper, digit, symbol, other bool
+	for _, r := range password {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+	pool := 0
+	for _, class := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.used {
+			pool += class.size
+		}
+	}
+	if pool == 0 {
+		return PasswordStrength{Score: 0, Label: strengthLabels[0]}
+	}
+
+	bits := float64(len([]rune(password))) * math.Log2(float64(pool))
+	score := 0
+	for _, threshold := range []float64{28, 36, 60, 128} {
+		if bits >= threshold {
+			score++
+		}
+	}
+	return PasswordStrength{Score: score, Label: strengthLabels[score]}
+}
+
+// PasswordRule is something a strong password has, along with what to tell the user
+// when the password is missing it.
+type PasswordRule struct {
+	Missing string
+	Check   func(password string) bool
+}
+
+/*
+RuleStrength returns a Strength that rates a password by how many of the rules it
+follows. The label says what the first rule it breaks is missing, or that the password is
+strong if it follows them all.
+
+	prompt := &survey.Password{
+		Message: "Choose a password",
+		Strength: survey.RuleStrength(
+			survey.PasswordRule{Missing: "at least 12 characters", Check: func(p string) bool { return len(p) >= 12 }},
+			survey.PasswordRule{Missing: "a digit", Check: func(p string) bool { return strings.ContainsAny(p, "0123456789") }},
+		),
+	}
+*/
+func RuleStrength(rules ...PasswordRule) func(password string) PasswordStrength {
+	return func(password string) PasswordStrength {
+		followed := 0
+		label := ""
+		for _, rule := range rules {
+			if rule.Check(password) {
+				followed++
+			} else if label == "" {
+				label = "needs " + rule.Missing
+			}
+		}
+		if label == "" {
+			return PasswordStrength{Score: len(strengthLabels) - 1, Label: "strong"}
+		}
+		return PasswordStrength{Score: followed * (len(strengthLabels) - 1) / len(rules), Label: label}
+	}
 }
@@ -2,9 +2,11 @@ package survey
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
 	expect "github.com/Netflix/go-expect"
 	"github.com/stretchr/testify/assert"
 )
@@ -40,6 +42,30 @@ func TestPasswordRender(t *testing.T) {
 			PasswordTemplateData{ShowHelp: true},
 			fmt.Sprintf("%s This is helpful\n%s Tell me your secret: ", defaultIcons().Help.Text, defaultIcons().Question.Text),
 		},
+		{
+			"Test Password question output while typing",
+			Password{Message: "Tell me your secret:", Reveal: true},
+			PasswordTemplateData{Input: "****"},
+			fmt.Sprintf("%s Tell me your secret: [tab to show] ****", defaultIcons().Question.Text),
+		},
+		{
+			"Test Password question output with strength",
+			Password{Message: "Tell me your secret:"},
+			PasswordTemplateData{Input: "****", Rating: &PasswordStrength{Score: 1, Label: "weak"}, Meter: "[#---]"},
+			fmt.Sprintf("%s Tell me your secret: ****\n  [#---] weak", defaultIcons().Question.Text),
+		},
+		{
+			"Test Password question output when confirming",
+			Password{Message: "Tell me your secret:"},
+			PasswordTemplateData{Confirming: true},
+			fmt.Sprintf("%s Type it again: ", defaultIcons().Question.Text),
+		},
+		{
+			"Test Password answer output",
+			Password{Message: "Tell me your secret:"},
+			PasswordTemplateData{Answer: "********", ShowAnswer: true},
+			fmt.Sprintf("%s Tell me your secret: ********\n", defaultIcons().Question.Text),
+		},
 	}
 
 	for _, test := range tests {
@@ -72,6 +98,21 @@ func TestPasswordPrompt(t *testing.T) {
 			},
 			"secret",
 		},
+		{
+			"Test Password prompt interaction with editing in the middle",
+			&Password{
+				Message: "Please type your password",
+			},
+			func(c *expect.Console) {
+				c.ExpectString("Please type your password")
+				c.Send("secrt")
+				c.Send(string(terminal.KeyArrowLeft))
+				c.Send("e")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			"secret",
+		},
 		{
 			"Test Password prompt interaction with help",
 			&Password{
@@ -88,6 +129,61 @@ func TestPasswordPrompt(t *testing.T) {
 			},
 			"secret",
 		},
+		{
+			"Test Password prompt interaction with confirmation",
+			&Password{
+				Message: "Please type your password",
+				Confirm: true,
+			},
+			func(c *expect.Console) {
+				c.ExpectString("Please type your password")
+				c.SendLine("secret")
+				c.ExpectString("Type it again:")
+				c.SendLine("secert")
+				// typing it differently starts over
+				c.ExpectString("the passwords don't match")
+				c.SendLine("hunter2")
+				c.ExpectString("Type it again:")
+				c.SendLine("hunter2")
+				c.ExpectEOF()
+			},
+			"hunter2",
+		},
+		{
+			"Test Password prompt interaction with strength",
+			&Password{
+				Message:  "Please type your password",
+				Strength: EntropyStrength,
+			},
+			func(c *expect.Console) {
+				c.ExpectString("Please type your password")
+				c.Send("abc")
+				c.ExpectString("[----] very weak")
+				c.Send("DEF123!?ghi")
+				c.ExpectString("[###-] strong")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			"abcDEF123!?ghi",
+		},
+		{
+			"Test Password prompt interaction with reveal",
+			&Password{
+				Message: "Please type your password",
+				Reveal:  true,
+			},
+			func(c *expect.Console) {
+				c.ExpectString("[tab to show]")
+				c.Send("secret")
+				c.Send(string(terminal.KeyTab))
+				c.ExpectString("[tab to hide]")
+				c.ExpectString("secret")
+				c.Send(string(terminal.KeyBackspace))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			"secre",
+		},
 	}
 
 	for _, test := range tests {
@@ -96,3 +192,33 @@ func TestPasswordPrompt(t *testing.T) {
 		})
 	}
 }
+
+func TestEntropyStrength(t *testing.T) {
+	tests := []struct {
+		password string
+		score    int
+	}{
+		{"", 0},
+		{"abc", 0},
+		{"abcdefgh", 2},
+		{"Tr0ub4dor&3", 3},
+		{strings.Repeat("correct horse battery staple ", 2), 4},
+	}
+
+	for _, test := range tests {
+		strength := EntropyStrength(test.password)
+		assert.Equal(t, test.score, strength.Score, test.password)
+		assert.Equal(t, strengthLabels[test.score], strength.Label, test.password)
+	}
+}
+
+func TestRuleStrength(t *testing.T) {
+	strength := RuleStrength(
+		PasswordRule{Missing: "8 characters", Check: func(p string) bool { return len(p) >= 8 }},
+		PasswordRule{Missing: "a digit", Check: func(p string) bool { return strings.ContainsAny(p, "0123456789") }},
+	)
+
+	assert.Equal(t, PasswordStrength{Score: 0, Label: "needs 8 characters"}, strength("abc"))
+	assert.Equal(t, PasswordStrength{Score: 2, Label: "needs a digit"}, strength("abcdefgh"))
+	assert.Equal(t, PasswordStrength{Score: 4, Label: "strong"}, strength("abcdefg1"))
+}
@@ -0,0 +1,225 @@
+package survey
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/*
+Path is an Input for paths on the local filesystem. Pressing tab completes the
+directories and files that start with what the user typed, and a leading "~" stands
+for the user's home directory. Relative paths are read from Base, which defaults to
+the working directory. Response type is a string, which is the path joined with Base
+and with "~" expanded.
+
+	config := ""
+	prompt := &survey.Path{
+		Message:    "Which config file should we use?",
+		FilesOnly:  true,
+		MustExist:  true,
+		Extensions: []string{".yaml", ".yml"},
+	}
+	survey.AskOne(prompt, &config)
+*/
+type Path struct {
+	Renderer
+	Message    string
+	Default    string
+	Help       string
+	Base       string
+	FilesOnly  bool
+	DirsOnly   bool
+	MustExist  bool
+	Extensions []string
+	// the answer given last time, when going back to the question
+	previous *string
+}
+
+func (p *Path) Prompt(config *PromptConfig) (interface{}, error) {
+	for {
+		input := p.input()
+		ans, err := input.Prompt(config)
+		// keep track of what the input printed so we can clean it up
+		p.Renderer = input.Renderer
+		if err != nil {
+			return ans, err
+		}
+
+		path := p.resolve(ans.(string))
+		invalid := p.check(path)
+		// if the user picked a path we can use we're done
+		if invalid == nil {
+			return path, nil
+		}
+		// otherwise tell them what's wrong and ask again
+		if err := p.Error(config, invalid); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// input returns the Input that asks for the path, printing where the path prompt does.
+func (p *Path) input() *Input {
+	return &Input{
+		Renderer: p.Renderer,
+		Message:  p.Message,
+		Default:  p.prefilled().Default,
+		Help:     p.Help,
+		Suggest:  p.suggest,
+	}
+}
+
+// suggest lists the paths that start with what the user typed.
+func (p *Path) suggest(toComplete string) []string {
+	if toComplete == "~" {
+		toComplete += string(filepath.Separator)
+	}
+	// the suggestions keep the directory the way the user typed it
+	dir, prefix := filepath.Split(toComplete)
+	root := dir
+	if root == "" {
+		root = "."
+	}
+
+	entries, err := ioutil.ReadDir(p.resolve(root))
+	if err != nil {
+		return nil
+	}
+
+	suggestions := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		// only show hidden files if the user asked for them
+		if !strings.HasPrefix(name, prefix) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(prefix, ".")) {
+			continue
+		}
+
+		if entry.IsDir() {
+			suggestions = append(suggestions, dir+name+string(filepath.Separator))
+		} else if !p.DirsOnly && p.hasExtension(name) {
+			suggestions = append(suggestions, dir+name)
+		}
+	}
+	return suggestions
+}
+
+// resolve expands a leading "~" in the path and joins it with Base.
+func (p *Path) resolve(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	if path == "~" || strings.HasPrefix(path, "~"+string(filepath.Separator)) || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[1:])
+		}
+	}
+	if p.Base != "" && !filepath.IsAbs(path) {
+		path = filepath.Join(p.Base, path)
+	}
+	return path
+}
+
+// check makes sure the path is one the prompt accepts.
+func (p *Path) check(path string) error {
+	if path == "" {
+		if p.MustExist {
+			return fmt.Errorf("a path is required")
+		}
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		if p.MustExist {
+			return fmt.Errorf("%s does not exist", path)
+		}
+	} else if err != nil {
+		return err
+	} else if p.FilesOnly && info.IsDir() {
+		return fmt.Errorf("%s is a directory", path)
+	} else if p.DirsOnly && !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", path)
+	}
+
+	if !p.DirsOnly && (info == nil || !info.IsDir()) && !p.hasExtension(path) {
+		return fmt.Errorf("%s does not end in %s", path, strings.Join(p.Extensions, ", "))
+	}
+	return nil
+}
+
+// hasExtension returns true if the file ends in one of the extensions, or there are no extensions to check.
+func (p *Path) hasExtension(file string) bool {
+	if len(p.Extensions) == 0 {
+		return true
+	}
+
+	ext := strings.TrimPrefix(filepath.Ext(file), ".")
+	for _, allowed := range p.Extensions {
+		if strings.EqualFold(ext, strings.TrimPrefix(allowed, ".")) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultAnswer returns the answer the user would get by just pressing enter.
+func (p *Path) DefaultAnswer() (interface{}, error) {
+	dflt := p.prefilled().Default
+	if dflt == "" {
+		return nil, ErrNoDefault
+	}
+	return p.ConvertAnswer(dflt)
+}
+
+// ConvertAnswer turns a supplied answer into a path, checking it the same way the
+// prompt checks what the user typed.
+func (p *Path) ConvertAnswer(value interface{}) (interface{}, error) {
+	text, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("cannot use a %T as a path", value)
+	}
+
+	path := p.resolve(text)
+	if err := p.check(path); err != nil {
+		return nil, err
+	}
+	return path, nil
+}
+
+// prefill makes an earlier answer the default when going back to the question, or
+// goes back to Default when there is none.
+func (p *Path) prefill(ans interface{}) {
+	p.previous = nil
+	val, ok := ans.(string)
+	if !ok {
+		return
+	}
+	// the answer was already joined with the base
+	if p.Base != "" {
+		if rel, err := filepath.Rel(p.Base, val); err == nil {
+			val = rel
+		}
+	}
+	p.previous = &val
+}
+
+// prefilled is the prompt as the user sees it, with the earlier answer in place of
+// Default.
+func (p *Path) prefilled() Path {
+	prompt := *p
+	if p.previous != nil {
+		prompt.Default = *p.previous
+	}
+	return prompt
+}
+
+func (p *Path) Cleanup(config *PromptConfig, val interface{}) error {
+	input := p.input()
+	err := input.Cleanup(config, val)
+	p.Renderer = input.Renderer
+	return err
+}
@@ -0,0 +1,127 @@
+package survey
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	expect "github.com/Netflix/go-expect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pathFixture creates a directory with a few files to complete, which the caller
+// has to remove.
+func pathFixture(t *testing.T) string {
+	base, err := ioutil.TempDir("", "survey")
+	require.Nil(t, err)
+	for _, dir := range []string{"config", ".git"} {
+		require.Nil(t, os.Mkdir(filepath.Join(base, dir), 0755))
+	}
+	for _, file := range []string{"config.yaml", "config/app.yml", "main.go"} {
+		require.Nil(t, ioutil.WriteFile(filepath.Join(base, file), []byte{}, 0644))
+	}
+	return base
+}
+
+func TestPathSuggest(t *testing.T) {
+	base := pathFixture(t)
+	defer os.RemoveAll(base)
+	sep := string(filepath.Separator)
+
+	tests := []struct {
+		prompt   Path
+		typed    string
+		expected []string
+	}{
+		{Path{Base: base}, "", []string{"config" + sep, "config.yaml", "main.go"}},
+		{Path{Base: base}, "con", []string{"config" + sep, "config.yaml"}},
+		{Path{Base: base}, ".", []string{".git" + sep}},
+		{Path{Base: base}, "config" + sep, []string{"config" + sep + "app.yml"}},
+		{Path{Base: base, DirsOnly: true}, "", []string{"config" + sep}},
+		{Path{Base: base, Extensions: []string{".go"}}, "", []string{"config" + sep, "main.go"}},
+		{Path{Base: base}, "missing" + sep, nil},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.prompt.suggest(test.typed), "completing %q", test.typed)
+	}
+}
+
+func TestPathConvertAnswer(t *testing.T) {
+	base := pathFixture(t)
+	defer os.RemoveAll(base)
+
+	answer, err := (&Path{Base: base, FilesOnly: true, MustExist: true}).ConvertAnswer("main.go")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(base, "main.go"), answer)
+
+	home, err := os.UserHomeDir()
+	require.Nil(t, err)
+	answer, err = (&Path{Base: base}).ConvertAnswer("~/notes.txt")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(home, "notes.txt"), answer)
+
+	tests := []struct {
+		prompt   Path
+		value    string
+		expected string
+	}{
+		{Path{Base: base, MustExist: true}, "missing.go", filepath.Join(base, "missing.go") + " does not exist"},
+		{Path{Base: base, FilesOnly: true}, "config", filepath.Join(base, "config") + " is a directory"},
+		{Path{Base: base, DirsOnly: true}, "main.go", filepath.Join(base, "main.go") + " is not a directory"},
+		{Path{Base: base, Extensions: []string{".yaml", ".yml"}}, "main.go", filepath.Join(base, "main.go") + " does not end in .yaml, .yml"},
+	}
+	for _, test := range tests {
+		_, err := test.prompt.ConvertAnswer(test.value)
+		if assert.NotNil(t, err, test.value) {
+			assert.Equal(t, test.expected, err.Error())
+		}
+	}
+}
+
+func TestPathPrompt(t *testing.T) {
+	base := pathFixture(t)
+	defer os.RemoveAll(base)
+
+	tests := []PromptTest{
+		{
+			"Test Path prompt completes a directory",
+			&Path{Message: "Which file?", Base: base},
+			func(c *expect.Console) {
+				c.ExpectString("Which file?")
+				c.Send("config/")
+				c.Send(string(terminal.KeyTab))
+				c.ExpectString("app.yml")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			filepath.Join(base, "config", "app.yml"),
+		},
+		{
+			"Test Path prompt asks again for a missing path",
+			&Path{Message: "Which file?", Base: base, MustExist: true},
+			func(c *expect.Console) {
+				c.ExpectString("Which file?")
+				c.SendLine("nope")
+				c.ExpectString("nope does not exist")
+				c.SendLine("main.go")
+				c.ExpectEOF()
+			},
+			filepath.Join(base, "main.go"),
+		},
+	}
+
+	// the prompt tests run in parallel, so group them to keep the fixture around until
+	// they are done
+	t.Run("group", func(t *testing.T) {
+		for _, test := range tests {
+			test := test
+			t.Run(test.name, func(t *testing.T) {
+				RunPromptTest(t, test)
+			})
+		}
+	})
+}
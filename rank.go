@@ -0,0 +1,264 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+/*
+Rank is a prompt that lets the user put a list of options in order. Space picks up the
+selected option, the arrow keys move it through the list and space drops it again.
+Response type is a []core.OptionAnswer in the new order, which can be written to a
+slice of strings or ints.
+
+	order := []string{}
+	prompt := &survey.Rank{
+		Message: "In which order should we deploy?",
+		Options: []string{"database", "api", "web"},
+	}
+	survey.AskOne(prompt, &order)
+*/
+type Rank struct {
+	Renderer
+	Message       string
+	Options       []string
+	Default       interface{}
+	Help          string
+	PageSize      int
+	order         []core.OptionAnswer
+	selectedIndex int
+	holding       bool
+	showingHelp   bool
+	// the answer given last time, when going back to the question
+	previous []core.OptionAnswer
+}
+
+// RankTemplateData is the data available to the templates when processing
+type RankTemplateData struct {
+	Rank
+	PageEntries   []core.OptionAnswer
+	SelectedIndex int
+	Holding       bool
+	Answer        string
+	ShowAnswer    bool
+	ShowHelp      bool
+	Config        *PromptConfig
+}
+
+var RankQuestionTemplate = `
+{{- if .ShowHelp }}{{- color .Config.Icons.Help.Format }}{{ .Config.Icons.Help.Text }} {{ .Help }}{{color "reset"}}{{"\n"}}{{end}}
+{{- color .Config.Icons.Question.Format }}{{ .Config.Icons.Question.Text }} {{color "reset"}}
+{{- color "default+hb"}}{{ .Message }}{{color "reset"}}
+{{- if .ShowAnswer}}{{color "cyan"}} {{.Answer}}{{color "reset"}}{{"\n"}}
+{{- else}}
+  {{- "  "}}{{- color "cyan"}}
+  {{- if .Holding}}[Use arrows to move the option, space to drop it]
+  {{- else}}[Use arrows to move, space to pick up an option, enter to submit{{- if and .Help (not .ShowHelp)}}, {{ .Config.HelpInput }} for more help{{end}}]
+  {{- end}}{{color "reset"}}
+  {{- "\n"}}
+  {{- range $ix, $option := .PageEntries}}
+    {{- if eq $ix $.SelectedIndex }}{{color $.Config.Icons.SelectFocus.Format }}{{ $.Config.Icons.SelectFocus.Text }} {{else}}{{color "default"}}  {{end}}
+    {{- if and (eq $ix $.SelectedIndex) $.Holding }}[{{ $option.Value }}]{{else}}{{ $option.Value }}{{end}}
+    {{- color "reset"}}{{"\n"}}
+  {{- end}}
+{{- end}}`
+
+func (r *Rank) Prompt(config *PromptConfig) (interface{}, error) {
+	// start off with the default order
+	order, err := r.DefaultAnswer()
+	if err != nil {
+		return nil, err
+	}
+	r.order = order.([]core.OptionAnswer)
+	r.selectedIndex = 0
+	r.holding = false
+	r.showingHelp = false
+
+	cursor := r.NewCursor()
+	cursor.Hide()       // hide the cursor
+	defer cursor.Show() // show the cursor when we're done
+
+	// ask the question
+	err = r.render(config)
+	if err != nil {
+		return nil, err
+	}
+
+	rr := r.NewRuneReader()
+	rr.SetTermMode()
+	defer rr.RestoreTermMode()
+
+	// start waiting for input
+	for {
+		key, _, err := rr.ReadRune()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case key == terminal.KeyInterrupt:
+			return nil, terminal.InterruptErr
+		case key == terminal.SpecialKeyShiftTab:
+			return nil, terminal.GoBackErr
+		case key == terminal.KeyEnter || key == '\n' || key == terminal.KeyEndTransmission:
+			return r.order, nil
+		case key == terminal.KeySpace:
+			r.holding = !r.holding
+		case key == terminal.KeyArrowUp:
+			r.move(-1)
+		case key == terminal.KeyArrowDown:
+			r.move(1)
+		case string(key) == config.HelpInput && r.Help != "":
+			r.showingHelp = true
+		}
+
+		err = r.render(config)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// move moves the cursor through the list, taking the option along if the user is holding it.
+func (r *Rank) move(step int) {
+	next := r.selectedIndex + step
+
+	// options that are held stop at the ends of the list
+	if r.holding {
+		if next < 0 || next >= len(r.order) {
+			return
+		}
+		r.order[r.selectedIndex], r.order[next] = r.order[next], r.order[r.selectedIndex]
+		r.selectedIndex = next
+		return
+	}
+
+	// otherwise wrap around like a select
+	r.selectedIndex = (next + len(r.order)) % len(r.order)
+}
+
+func (r *Rank) render(config *PromptConfig) error {
+	// figure out the page size
+	pageSize := r.PageSize
+	// if we dont have a specific one
+	if pageSize == 0 {
+		// grab the global value
+		pageSize = config.PageSize
+	}
+
+	opts, idx := paginate(pageSize, r.order, r.selectedIndex)
+
+	return r.Render(
+		RankQuestionTemplate,
+		RankTemplateData{
+			Rank:          *r,
+			PageEntries:   opts,
+			SelectedIndex: idx,
+			Holding:       r.holding,
+			ShowHelp:      r.showingHelp,
+			Config:        config,
+		},
+	)
+}
+
+// DefaultAnswer returns the order the options start in, which is the order of the
+// default followed by the rest of the options.
+func (r *Rank) DefaultAnswer() (interface{}, error) {
+	// if there are no options to render
+	if len(r.Options) == 0 {
+		// we failed
+		return nil, errors.New("please provide options to rank")
+	}
+
+	order := []core.OptionAnswer{}
+	if dflt := r.prefilled().Default; dflt != nil {
+		defaults, err := findOptions(nil, r.Options, dflt)
+		if err != nil {
+			return nil, err
+		}
+		order = defaults
+	}
+
+	// add the options the default leaves out
+	ranked := map[int]bool{}
+	for _, opt := range order {
+		ranked[opt.Index] = true
+	}
+	for _, opt := range core.OptionAnswerList(r.Options) {
+		if !ranked[opt.Index] {
+			order = append(order, opt)
+		}
+	}
+	return order, nil
+}
+
+// ConvertAnswer turns a supplied answer into an order of the options. The answer can be
+// a list of option values or indices, or a []core.OptionAnswer, and has to hold every
+// option exactly once.
+func (r *Rank) ConvertAnswer(value interface{}) (interface{}, error) {
+	order, err := findOptions(nil, r.Options, value)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := map[int]bool{}
+	for _, opt := range order {
+		if ranked[opt.Index] {
+			return nil, fmt.Errorf("%q is ranked more than once", opt.Value)
+		}
+		ranked[opt.Index] = true
+	}
+	if len(order) != len(r.Options) {
+		return nil, fmt.Errorf("all %d options have to be ranked", len(r.Options))
+	}
+	return order, nil
+}
+
+// prefill makes an earlier answer the default when going back to the question, or
+// goes back to Default when there is none.
+func (r *Rank) prefill(ans interface{}) {
+	r.previous = nil
+	if vals, ok := ans.([]core.OptionAnswer); ok {
+		r.previous = vals
+	}
+}
+
+// prefilled is the prompt as the user sees it, with the earlier answer in place of
+// Default.
+func (r *Rank) prefilled() Rank {
+	prompt := *r
+	if r.previous != nil {
+		prompt.Default = r.previous
+	}
+	return prompt
+}
+
+// formatAnswer returns the answer the way it is shown once the question is answered.
+func (r *Rank) formatAnswer(val interface{}) string {
+	// transformers can turn the answer into something other than the ranked options
+	answers, ok := val.([]core.OptionAnswer)
+	if !ok {
+		return fmt.Sprint(val)
+	}
+	values := []string{}
+	for _, ans := range answers {
+		values = append(values, ans.Value)
+	}
+	return strings.Join(values, ", ")
+}
+
+func (r *Rank) Cleanup(config *PromptConfig, val interface{}) error {
+	return r.Render(
+		RankQuestionTemplate,
+		RankTemplateData{
+			Rank:       *r,
+			Answer:     r.formatAnswer(val),
+			ShowAnswer: true,
+			Config:     config,
+		},
+	)
+}
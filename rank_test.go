@@ -0,0 +1,168 @@
+package survey
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	expect "github.com/Netflix/go-expect"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	// disable color output for all prompts to simplify testing
+	core.DisableColor = true
+}
+
+func TestRankRender(t *testing.T) {
+	prompt := Rank{
+		Message: "Deploy order?",
+		Options: []string{"database", "api", "web"},
+	}
+
+	tests := []struct {
+		title    string
+		data     RankTemplateData
+		expected string
+	}{
+		{
+			"Test Rank question output",
+			RankTemplateData{SelectedIndex: 1},
+			fmt.Sprintf(
+				"%s Deploy order?  [Use arrows to move, space to pick up an option, enter to submit]\n  database\n%s api\n  web\n",
+				defaultIcons().Question.Text, defaultIcons().SelectFocus.Text,
+			),
+		},
+		{
+			"Test Rank question output holding an option",
+			RankTemplateData{SelectedIndex: 1, Holding: true},
+			fmt.Sprintf(
+				"%s Deploy order?  [Use arrows to move the option, space to drop it]\n  database\n%s [api]\n  web\n",
+				defaultIcons().Question.Text, defaultIcons().SelectFocus.Text,
+			),
+		},
+		{
+			"Test Rank answer output",
+			RankTemplateData{Answer: "api, database, web", ShowAnswer: true},
+			fmt.Sprintf("%s Deploy order? api, database, web\n", defaultIcons().Question.Text),
+		},
+	}
+
+	for _, test := range tests {
+		r, w, err := os.Pipe()
+		assert.Nil(t, err, test.title)
+
+		prompt.WithStdio(terminal.Stdio{Out: w})
+		test.data.Rank = prompt
+		test.data.PageEntries = core.OptionAnswerList(prompt.Options)
+
+		// set the runtime config
+		test.data.Config = defaultPromptConfig()
+
+		err = prompt.Render(
+			RankQuestionTemplate,
+			test.data,
+		)
+		assert.Nil(t, err, test.title)
+
+		w.Close()
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+
+		assert.Contains(t, buf.String(), test.expected, test.title)
+	}
+}
+
+func TestRankPrompt(t *testing.T) {
+	tests := []PromptTest{
+		{
+			"Test Rank prompt interaction",
+			&Rank{Message: "Deploy order?", Options: []string{"database", "api", "web"}},
+			func(c *expect.Console) {
+				c.ExpectString("Deploy order?")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			[]core.OptionAnswer{{Value: "database", Index: 0}, {Value: "api", Index: 1}, {Value: "web", Index: 2}},
+		},
+		{
+			"Test Rank prompt moves options",
+			&Rank{Message: "Deploy order?", Options: []string{"database", "api", "web"}},
+			func(c *expect.Console) {
+				c.ExpectString("Deploy order?")
+				// carry the database to the bottom, past the end of the list
+				c.Send(" ")
+				c.Send(string(terminal.KeyArrowDown))
+				c.Send(string(terminal.KeyArrowDown))
+				c.Send(string(terminal.KeyArrowDown))
+				c.Send(" ")
+				// and the web server to the top
+				c.Send(string(terminal.KeyArrowUp))
+				c.Send(" ")
+				c.Send(string(terminal.KeyArrowUp))
+				c.Send(string(terminal.KeyArrowUp))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			[]core.OptionAnswer{{Value: "web", Index: 2}, {Value: "api", Index: 1}, {Value: "database", Index: 0}},
+		},
+		{
+			"Test Rank prompt interaction with default",
+			&Rank{Message: "Deploy order?", Options: []string{"database", "api", "web"}, Default: []string{"web"}},
+			func(c *expect.Console) {
+				c.ExpectString("Deploy order?")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			[]core.OptionAnswer{{Value: "web", Index: 2}, {Value: "database", Index: 0}, {Value: "api", Index: 1}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			RunPromptTest(t, test)
+		})
+	}
+}
+
+func TestRankConvertAnswer(t *testing.T) {
+	prompt := &Rank{Options: []string{"database", "api", "web"}}
+
+	answer, err := prompt.ConvertAnswer([]string{"web", "api", "database"})
+	assert.Nil(t, err)
+	assert.Equal(t, []core.OptionAnswer{{Value: "web", Index: 2}, {Value: "api", Index: 1}, {Value: "database", Index: 0}}, answer)
+
+	for _, value := range []interface{}{[]string{"web", "api"}, []int{0, 0, 1}, []string{"web", "api", "cache"}} {
+		_, err := prompt.ConvertAnswer(value)
+		assert.NotNil(t, err, "converting %v", value)
+	}
+}
+
+func TestRankFormatAnswer(t *testing.T) {
+	prompt := &Rank{Options: []string{"database", "api", "web"}}
+
+	assert.Equal(t, "web, api", prompt.formatAnswer([]core.OptionAnswer{{Value: "web", Index: 2}, {Value: "api", Index: 1}}))
+	// answers changed by a transformer are shown as they are
+	assert.Equal(t, "[web api]", prompt.formatAnswer([]string{"web", "api"}))
+}
+
+func TestAskOne_rank(t *testing.T) {
+	prompt := &Rank{Options: []string{"database", "api", "web"}}
+	answers := WithAnswers(map[string]interface{}{"": []int{1, 2, 0}})
+
+	in, out, closeStdio := pipeStdio(t)
+	defer closeStdio()
+	names := []string{}
+	err := AskOne(prompt, &names, WithStdio(in, out, out), answers)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"api", "web", "database"}, names)
+
+	indices := []int{}
+	err = AskOne(prompt, &indices, WithStdio(in, out, out), answers)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 0}, indices)
+}
@@ -0,0 +1,80 @@
+package survey
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+// errWoken is returned by a read that was interrupted to let the prompt show something
+// new, like options that finished loading. Reading again picks up where it left off.
+var errWoken = errors.New("woken up while waiting for input")
+
+// cancelableReader wraps the input that prompts read from so that a read blocked
+// waiting on the user can be abandoned as soon as the current context is done, or
+// something is sent on wake. The underlying read keeps going in the background and
+// its result is handed to the next call to Read so no input is lost between questions.
+type cancelableReader struct {
+	in      terminal.FileReader
+	ctx     context.Context
+	wake    chan struct{}
+	results chan readResult
+	pending bool
+	buf     []byte
+}
+
+type readResult struct {
+	buf []byte
+	err error
+}
+
+func newCancelableReader(ctx context.Context, in terminal.FileReader) *cancelableReader {
+	return &cancelableReader{
+		in:      in,
+		ctx:     ctx,
+		results: make(chan readResult, 1),
+	}
+}
+
+// Fd returns the file descriptor of the wrapped input so the terminal mode can
+// still be changed through the wrapper.
+func (r *cancelableReader) Fd() uintptr {
+	return r.in.Fd()
+}
+
+func (r *cancelableReader) Read(p []byte) (int, error) {
+	// hand out anything left over from the last read first
+	if len(r.buf) > 0 {
+		n := copy(p, r.buf)
+		r.buf = r.buf[n:]
+		return n, nil
+	}
+
+	// if we have already been cancelled there's no point in waiting
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	// only start reading again if the previous read has been collected
+	if !r.pending {
+		r.pending = true
+		go func(size int) {
+			buf := make([]byte, size)
+			n, err := r.in.Read(buf)
+			r.results <- readResult{buf: buf[:n], err: err}
+		}(len(p))
+	}
+
+	select {
+	case <-r.ctx.Done():
+		return 0, r.ctx.Err()
+	case <-r.wake:
+		return 0, errWoken
+	case res := <-r.results:
+		r.pending = false
+		n := copy(p, res.buf)
+		r.buf = res.buf[n:]
+		return n, res.err
+	}
+}
@@ -75,6 +75,23 @@ func (r *Renderer) resetPrompt(lines int) {
 	}
 }
 
+// clear erases everything the renderer has printed for the current prompt,
+// including any error, and makes sure the cursor is visible again.
+func (r *Renderer) clear() {
+	r.resetPrompt(r.lineCount + r.errorLineCount)
+	r.lineCount = 0
+	r.errorLineCount = 0
+
+	r.NewCursor().Show()
+}
+
+// forget makes the renderer leave alone whatever it has printed so far, so the
+// prompt can be shown again underneath it.
+func (r *Renderer) forget() {
+	r.lineCount = 0
+	r.errorLineCount = 0
+}
+
 func (r *Renderer) Render(tmpl string, data interface{}) error {
 	r.resetPrompt(r.lineCount)
 	// render the template summarizing the current state
@@ -0,0 +1,111 @@
+package survey
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2/core"
+)
+
+// ReplayError is returned by Ask when answers replayed with WithReplay no longer pass
+// the questions' validators. It holds the reason each answer failed, keyed by the
+// name of the question.
+type ReplayError map[string]error
+
+func (e ReplayError) Error() string {
+	names := []string{}
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	problems := []string{}
+	for _, name := range names {
+		problems = append(problems, fmt.Sprintf("%s: %v", name, e[name]))
+	}
+	return fmt.Sprintf("replayed answers are no longer valid: %s", strings.Join(problems, "; "))
+}
+
+// recordAnswers writes the answers to the given questions to a JSON file, keyed by
+// question name. Passwords are left out so they never end up on disk.
+func recordAnswers(path string, qs []*Question, answered []int, raws []interface{}) error {
+	answers := map[string]interface{}{}
+	for _, i := range answered {
+		if _, ok := qs[i].Prompt.(*Password); ok {
+			continue
+		}
+		answers[qs[i].Name] = raws[i]
+	}
+
+	contents, err := json.MarshalIndent(answers, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// the answers are only meant for whoever recorded them
+	return ioutil.WriteFile(path, append(contents, '\n'), 0600)
+}
+
+// readAnswers reads answers written by recordAnswers.
+func readAnswers(path string) (map[string]interface{}, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := map[string]interface{}{}
+	if err := json.Unmarshal(contents, &answers); err != nil {
+		return nil, fmt.Errorf("could not read answers from %s: %v", path, err)
+	}
+
+	// turn the options that were picked back into option answers
+	for name, ans := range answers {
+		answers[name] = replayedAnswer(ans)
+	}
+
+	return answers, nil
+}
+
+// replayedAnswer turns a decoded JSON value back into the option answers it was
+// recorded from, if it was one.
+func replayedAnswer(ans interface{}) interface{} {
+	switch val := ans.(type) {
+	case map[string]interface{}:
+		if option, ok := replayedOption(val); ok {
+			return option
+		}
+	case []interface{}:
+		options := []core.OptionAnswer{}
+		for _, item := range val {
+			fields, ok := item.(map[string]interface{})
+			if !ok {
+				return ans
+			}
+			option, ok := replayedOption(fields)
+			if !ok {
+				return ans
+			}
+			options = append(options, option)
+		}
+		if len(options) > 0 {
+			return options
+		}
+	}
+	return ans
+}
+
+// replayedOption turns the fields of a recorded core.OptionAnswer back into one.
+func replayedOption(fields map[string]interface{}) (core.OptionAnswer, bool) {
+	value, ok := fields["Value"].(string)
+	if !ok {
+		return core.OptionAnswer{}, false
+	}
+	index, ok := fields["Index"].(float64)
+	if !ok || len(fields) != 2 {
+		return core.OptionAnswer{}, false
+	}
+	return core.OptionAnswer{Value: value, Index: int(index)}, true
+}
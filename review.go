@@ -0,0 +1,87 @@
+package survey
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+var (
+	// ReviewMessage is shown above the summary of answers when using WithReview.
+	ReviewMessage = "Review your answers:"
+	// ReviewAccept is the entry in the summary that accepts every answer.
+	ReviewAccept = "Looks good"
+)
+
+// review shows the user a summary of the answered questions and returns the index of
+// the one they want to answer again, or -1 if they accepted every answer.
+func review(ctx context.Context, reader *cancelableReader, qs []*Question, history []int, answers map[string]interface{}, options *AskOptions) (int, error) {
+	// line the answers up in a table
+	width := 0
+	for _, i := range history {
+		if len(qs[i].Name) > width {
+			width = len(qs[i].Name)
+		}
+	}
+	entries := []string{ReviewAccept}
+	for _, i := range history {
+		name := qs[i].Name
+		entries = append(entries, fmt.Sprintf("%-*s  %s", width, name, reviewAnswer(qs[i].Prompt, answers[name])))
+	}
+
+	prompt := &Select{
+		Message: ReviewMessage,
+		Options: entries,
+	}
+	prompt.WithStdio(options.Stdio)
+
+	// the summary is not a real question so the global validators don't apply to it
+	reviewOptions := *options
+	reviewOptions.Validators = nil
+
+	ans, err := askQuestion(ctx, reader, &Question{Prompt: prompt}, &reviewOptions, nil)
+	// the summary makes way for whatever comes next
+	prompt.clear()
+	// going back from the summary just shows it again
+	if err == terminal.GoBackErr {
+		return review(ctx, reader, qs, history, answers, options)
+	}
+	if err != nil {
+		return -1, err
+	}
+
+	// the first entry accepts the answers
+	choice := ans.(core.OptionAnswer).Index
+	if choice <= 0 {
+		return -1, nil
+	}
+	return history[choice-1], nil
+}
+
+// reviewAnswer turns an answer into a string the same way the prompt showed it once the
+// question was answered.
+func reviewAnswer(prompt Prompt, ans interface{}) string {
+	if p, ok := prompt.(formatsAnswer); ok {
+		return p.formatAnswer(ans)
+	}
+
+	switch val := ans.(type) {
+	case string:
+		return val
+	case bool:
+		return yesNo(val)
+	case core.OptionAnswer:
+		return val.Value
+	case []core.OptionAnswer:
+		values := []string{}
+		for _, opt := range val {
+			values = append(values, opt.Value)
+		}
+		return strings.Join(values, ", ")
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
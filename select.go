@@ -2,6 +2,7 @@ package survey
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/AlecAivazis/survey/v2/core"
 	"github.com/AlecAivazis/survey/v2/terminal"
@@ -17,21 +18,32 @@ for them to select using the arrow keys and enter. Response type is a string.
 		Options: []string{"red", "blue", "green"},
 	}
 	survey.AskOne(prompt, &color)
+
+Choices can be given in place of Options to describe each option or disable some of them,
+and Load can load the options in the background as the user types to filter them, which
+only works when the prompt is asked with Ask or AskOne.
 */
 type Select struct {
 	Renderer
 	Message       string
 	Options       []string
+	Choices       []Choice
 	Default       interface{}
 	Help          string
 	PageSize      int
 	VimMode       bool
 	FilterMessage string
 	Filter        func(filter string, value string, index int) bool
+	Matcher       Matcher
+	Load          LoadOptions
 	filter        string
+	filtered      optionFilter
+	loader        *optionLoader
 	selectedIndex int
 	useDefault    bool
 	showingHelp   bool
+	// the answer given last time, when going back to the question
+	previous *string
 }
 
 // SelectTemplateData is the data available to the templates when processing
@@ -39,6 +51,11 @@ type SelectTemplateData struct {
 	Select
 	PageEntries   []core.OptionAnswer
 	SelectedIndex int
+	Description   string
+	Headers       map[int]string
+	Highlights    map[int][]MatchSegment
+	Loading       bool
+	LoadError     error
 	Answer        string
 	ShowAnswer    bool
 	ShowHelp      bool
@@ -54,21 +71,47 @@ var SelectQuestionTemplate = `
   {{- "  "}}{{- color "cyan"}}[Use arrows to move, type to filter{{- if and .Help (not .ShowHelp)}}, {{ .Config.HelpInput }} for more help{{end}}]{{color "reset"}}
   {{- "\n"}}
   {{- range $ix, $choice := .PageEntries}}
+    {{- with index $.Headers $ix}}{{color "default+hb"}}{{.}}{{color "reset"}}{{"\n"}}{{end}}
+    {{- $disabled := false}}{{if $.Choices}}{{$disabled = (index $.Choices $choice.Index).Disabled}}{{end}}
     {{- if eq $ix $.SelectedIndex }}{{color $.Config.Icons.SelectFocus.Format }}{{ $.Config.Icons.SelectFocus.Text }} {{else}}{{color "default"}}  {{end}}
-    {{- $choice.Value}}
+    {{- if $disabled}}{{color "black+h"}}{{end}}
+    {{- with index $.Highlights $choice.Index}}
+      {{- range .}}
+        {{- if .Matched}}{{color "cyan+bu"}}{{.Text}}{{color "reset"}}
+          {{- if $disabled}}{{color "black+h"}}{{else if eq $ix $.SelectedIndex}}{{color $.Config.Icons.SelectFocus.Format}}{{else}}{{color "default"}}{{end}}
+        {{- else}}{{.Text}}{{end}}
+      {{- end}}
+    {{- else}}{{$choice.Value}}{{end}}
+    {{- if $disabled}}{{with (index $.Choices $choice.Index).DisabledReason}} ({{.}}){{end}}{{end}}
     {{- color "reset"}}{{"\n"}}
   {{- end}}
+  {{- if .Loading}}{{color "cyan"}}  Loading...{{color "reset"}}{{"\n"}}{{end}}
+  {{- with .LoadError}}{{color "red"}}  Could not load the options: {{.Error}}{{color "reset"}}{{"\n"}}{{end}}
+  {{- if .Description}}{{color "cyan"}}{{ .Description }}{{color "reset"}}{{"\n"}}{{end}}
 {{- end}}`
 
 // OnChange is called on every keypress.
 func (s *Select) OnChange(key rune, config *PromptConfig) bool {
+	if s.handleKey(key, config) {
+		// we're done (stop prompting the user)
+		return true
+	}
+
+	s.render(config)
+	// keep prompting
+	return false
+}
+
+// handleKey updates the prompt for a key the user pressed, returning true once they
+// picked an option.
+func (s *Select) handleKey(key rune, config *PromptConfig) bool {
 	options := s.filterOptions(config)
 	oldFilter := s.filter
 
 	// if the user pressed the enter key and the index is a valid option
 	if key == terminal.KeyEnter || key == '\n' {
 		// if the selected index is a valid option
-		if len(options) > 0 && s.selectedIndex < len(options) {
+		if len(options) > 0 && s.selectedIndex < len(options) && !isDisabled(s.Choices, options[s.selectedIndex].Index) {
 
 			// we're done (stop prompting the user)
 			return true
@@ -81,26 +124,14 @@ func (s *Select) OnChange(key rune, config *PromptConfig) bool {
 	} else if key == terminal.KeyArrowUp || (s.VimMode && key == 'k') && len(options) > 0 {
 		s.useDefault = false
 
-		// if we are at the top of the list
-		if s.selectedIndex == 0 {
-			// start from the button
-			s.selectedIndex = len(options) - 1
-		} else {
-			// otherwise we are not at the top of the list so decrement the selected index
-			s.selectedIndex--
-		}
+		// move up, skipping the options that are disabled and wrapping around at the top
+		s.selectedIndex = focusEnabled(s.Choices, options, s.selectedIndex, -1)
 
 		// if the user pressed down or 'j' to emulate vim
 	} else if key == terminal.KeyArrowDown || (s.VimMode && key == 'j') && len(options) > 0 {
 		s.useDefault = false
-		// if we are at the bottom of the list
-		if s.selectedIndex == len(options)-1 {
-			// start from the top
-			s.selectedIndex = 0
-		} else {
-			// increment the selected index
-			s.selectedIndex++
-		}
+		// move down, skipping the options that are disabled and wrapping around at the bottom
+		s.selectedIndex = focusEnabled(s.Choices, options, s.selectedIndex, 1)
 		// only show the help message if we have one
 	} else if string(key) == config.HelpInput && s.Help != "" {
 		s.showingHelp = true
@@ -136,8 +167,17 @@ func (s *Select) OnChange(key rune, config *PromptConfig) bool {
 		if len(options) > 0 && len(options) <= s.selectedIndex {
 			s.selectedIndex = len(options) - 1
 		}
+		s.selectedIndex = focusEnabled(s.Choices, options, s.selectedIndex, 0)
 	}
 
+	// keep prompting
+	return false
+}
+
+func (s *Select) render(config *PromptConfig) error {
+	options := s.filterOptions(config)
+	match, _ := filterMatcher(s.Filter, s.Matcher, config)
+
 	// figure out the options and index to render
 	// figure out the page size
 	pageSize := s.PageSize
@@ -152,97 +192,71 @@ func (s *Select) OnChange(key rune, config *PromptConfig) bool {
 	opts, idx := paginate(pageSize, options, s.selectedIndex)
 
 	// render the options
-	s.Render(
+	return s.Render(
 		SelectQuestionTemplate,
 		SelectTemplateData{
 			Select:        *s,
 			SelectedIndex: idx,
+			Description:   choiceDescription(s.Choices, options, s.selectedIndex),
 			ShowHelp:      s.showingHelp,
 			PageEntries:   opts,
+			Headers:       groupHeaders(s.Choices, opts),
+			Highlights:    s.filtered.segments(match, opts),
+			Loading:       s.loader != nil && s.loader.loading,
+			LoadError:     s.loadError(),
 			Config:        config,
 		},
 	)
-
-	// keep prompting
-	return false
 }
 
+// filterOptions returns the options that match the filter. Group headers are shown for
+// the options that are left, so a group without any matches is hidden along with them.
 func (s *Select) filterOptions(config *PromptConfig) []core.OptionAnswer {
-	// the filtered list
-	answers := []core.OptionAnswer{}
-
-	// if there is no filter applied
-	if s.filter == "" {
-		return core.OptionAnswerList(s.Options)
+	// options that are loaded have already been filtered by whoever loaded them
+	if s.loader != nil {
+		return s.loader.view
 	}
 
-	// the filter to apply
-	filter := s.Filter
-	if filter == nil {
-		filter = config.Filter
+	// if we haven't seen the options yet
+	if s.filtered.all == nil {
+		s.filtered.reset(s.labels(), groupRuns(s.Choices))
 	}
 
-	//
-	for i, opt := range s.Options {
-		// i the filter says to include the option
-		if filter(s.filter, opt, i) {
-			answers = append(answers, core.OptionAnswer{
-				Index: i,
-				Value: opt,
-			})
-		}
-	}
-
-	// return the list of answers
-	return answers
+	// apply the filter, which only has to look at the options that matched before if
+	// the user kept typing
+	match, narrows := filterMatcher(s.Filter, s.Matcher, config)
+	return s.filtered.apply(match, narrows, s.filter)
 }
 
 func (s *Select) Prompt(config *PromptConfig) (interface{}, error) {
-	// if there are no options to render
-	if len(s.Options) == 0 {
+	// options that load in the background start off empty
+	s.loader = nil
+	stdio := s.Stdio()
+	if s.Load != nil {
+		s.loader = newOptionLoader(s.Load)
+		// read keys with a reader the loader can wake up when the options come back
+		in, restore, err := s.loader.wakeReader(stdio.In)
+		if err != nil {
+			return "", err
+		}
+		defer restore()
+		stdio.In = in
+		s.loader.start("")
+		defer s.loader.stop()
+		// if there are no options to render
+	} else if len(s.labels()) == 0 {
 		// we failed
 		return "", errors.New("please provide options to select from")
 	}
 
-	// start off with the first option selected
-	sel := 0
-	// if there is a default
-	if s.Default != "" {
-		// find the choice
-		for i, opt := range s.Options {
-			// if the option corresponds to the default
-			if opt == s.Default {
-				// we found our initial value
-				sel = i
-				// stop looking
-				break
-			}
-		}
-	}
-	// save the selected index
-	s.selectedIndex = sel
-
-	// figure out the page size
-	pageSize := s.PageSize
-	// if we dont have a specific one
-	if pageSize == 0 {
-		// grab the global value
-		pageSize = config.PageSize
-	}
-
-	// figure out the options and index to render
-	opts, idx := paginate(pageSize, core.OptionAnswerList(s.Options), sel)
+	// start off on the default, moving past the options that are disabled. Options that
+	// load get the cursor moved to the default once they come back
+	s.filtered.reset(s.labels(), groupRuns(s.Choices))
+	options := s.filterOptions(config)
+	s.selectedIndex = focusEnabled(s.Choices, options, s.defaultIndex(options), 0)
 
 	// ask the question
-	err := s.Render(
-		SelectQuestionTemplate,
-		SelectTemplateData{
-			Select:        *s,
-			PageEntries:   opts,
-			SelectedIndex: idx,
-			Config:        config,
-		},
-	)
+	err := s.render(config)
 	if err != nil {
 		return "", err
 	}
@@ -250,7 +264,7 @@ func (s *Select) Prompt(config *PromptConfig) (interface{}, error) {
 	// by default, use the default value
 	s.useDefault = true
 
-	rr := s.NewRuneReader()
+	rr := terminal.NewRuneReader(stdio)
 	rr.SetTermMode()
 	defer rr.RestoreTermMode()
 
@@ -261,20 +275,38 @@ func (s *Select) Prompt(config *PromptConfig) (interface{}, error) {
 	// start waiting for input
 	for {
 		r, _, err := rr.ReadRune()
+		if err == errWoken {
+			// the options for the filter came back
+			if s.loader.receive() {
+				s.showLoaded(config)
+			}
+			continue
+		}
 		if err != nil {
 			return "", err
 		}
 		if r == terminal.KeyInterrupt {
 			return "", terminal.InterruptErr
 		}
+		if r == terminal.SpecialKeyShiftTab {
+			return "", terminal.GoBackErr
+		}
 		if r == terminal.KeyEndTransmission {
 			break
 		}
-		if s.OnChange(r, config) {
+		filter := s.filter
+		if s.handleKey(r, config) {
 			break
 		}
+		if s.loader != nil && s.filter != filter {
+			s.loader.start(s.filter)
+		}
+		// wait until the keys the user typed ahead are handled before drawing the prompt
+		if rr.Buffered() == 0 {
+			s.render(config)
+		}
 	}
-	options := s.filterOptions(config)
+	options = s.filterOptions(config)
 	s.filter = ""
 	s.FilterMessage = ""
 
@@ -282,21 +314,9 @@ func (s *Select) Prompt(config *PromptConfig) (interface{}, error) {
 	var val string
 	// if we are supposed to use the default value
 	if s.useDefault || s.selectedIndex >= len(options) {
-		// if there is a default value
-		if s.Default != nil {
-			// if the default is a string
-			if defaultString, ok := s.Default.(string); ok {
-				// use the default value
-				val = defaultString
-				// the default value could also be an interpret which is interpretted as the index
-			} else if defaultIndex, ok := s.Default.(int); ok {
-				val = s.Options[defaultIndex]
-			} else {
-				return val, errors.New("default value of select must be an int or string")
-			}
-		} else if len(options) > 0 {
-			// there is no default value so use the first
-			val = options[0].Value
+		val, err = s.defaultValue(options)
+		if err != nil {
+			return val, err
 		}
 		// otherwise the selected index points to the value
 	} else if s.selectedIndex < len(options) {
@@ -304,15 +324,199 @@ func (s *Select) Prompt(config *PromptConfig) (interface{}, error) {
 		val = options[s.selectedIndex].Value
 	}
 
+	return s.answer(val), err
+}
+
+// showLoaded shows the options that were loaded for the filter, keeping the cursor in
+// the list and on the default if the user hasn't moved it yet.
+func (s *Select) showLoaded(config *PromptConfig) {
+	options := s.filterOptions(config)
+	if s.useDefault {
+		s.selectedIndex = focusEnabled(s.Choices, options, s.defaultIndex(options), 0)
+	}
+	if len(options) > 0 && len(options) <= s.selectedIndex {
+		s.selectedIndex = len(options) - 1
+	}
+	s.render(config)
+}
+
+// loadError returns why the options for the filter couldn't be loaded.
+func (s *Select) loadError() error {
+	if s.loader == nil {
+		return nil
+	}
+	return s.loader.err
+}
+
+// loadOptions loads the options up front if they come from Load, for answering the
+// question without the user.
+func (s *Select) loadOptions() error {
+	if s.Load == nil || s.loader != nil {
+		return nil
+	}
+	loader := newOptionLoader(s.Load)
+	if err := loader.loadNow(""); err != nil {
+		return err
+	}
+	s.loader = loader
+	return nil
+}
+
+func (s *Select) loadsOptions() bool {
+	return s.Load != nil
+}
+
+// DefaultAnswer returns the answer the user would get by accepting the prompt
+// without moving the cursor.
+func (s *Select) DefaultAnswer() (interface{}, error) {
+	if err := s.loadOptions(); err != nil {
+		return "", err
+	}
+	// if there are no options to choose from
+	if len(s.labels()) == 0 {
+		// we failed
+		return "", errors.New("please provide options to select from")
+	}
+
+	val, err := s.defaultValue(core.OptionAnswerList(s.labels()))
+	if err != nil {
+		return val, err
+	}
+
+	return s.answer(val), nil
+}
+
+// defaultIndex returns where the default is in the options, or 0 if it isn't one of them.
+func (s *Select) defaultIndex(options []core.OptionAnswer) int {
+	for i, opt := range options {
+		switch dflt := s.prefilled().Default.(type) {
+		case string:
+			if opt.Value == dflt {
+				return i
+			}
+		case int:
+			if opt.Index == dflt {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+// defaultValue returns the value of the default option, falling back to the first
+// of the given options if there is no default.
+func (s *Select) defaultValue(options []core.OptionAnswer) (string, error) {
+	dflt := s.prefilled().Default
+	// if there is a default value
+	if dflt != nil {
+		// if the default is a string
+		if defaultString, ok := dflt.(string); ok {
+			// use the default value
+			return defaultString, nil
+			// the default value could also be an interpret which is interpretted as the index
+		} else if defaultIndex, ok := dflt.(int); ok {
+			// options that load might not have come back yet
+			if defaultIndex < 0 || defaultIndex >= len(s.labels()) {
+				return "", fmt.Errorf("default index %d is not one of the options", defaultIndex)
+			}
+			return s.labels()[defaultIndex], nil
+		}
+		return "", errors.New("default value of select must be an int or string")
+	}
+
+	// there is no default value so use the first one that isn't disabled
+	if len(options) > 0 {
+		return options[focusEnabled(s.Choices, options, 0, 0)].Value, nil
+	}
+	return "", nil
+}
+
+// answer builds the response for the option with the given value.
+func (s *Select) answer(val string) core.OptionAnswer {
 	// now that we have the value lets go hunt down the right index to return
-	idx = -1
-	for i, optionValue := range s.Options {
+	idx := -1
+	for i, optionValue := range s.labels() {
 		if optionValue == val {
 			idx = i
 		}
 	}
 
-	return core.OptionAnswer{Value: val, Index: idx}, err
+	return choiceAnswer(s.Choices, core.OptionAnswer{Value: val, Index: idx})
+}
+
+// labels returns the options to show, which are the labels of the choices if there are any
+// or every option that was loaded.
+func (s *Select) labels() []string {
+	if s.loader != nil {
+		return s.loader.all
+	}
+	if len(s.Choices) > 0 {
+		return choiceLabels(s.Choices)
+	}
+	return s.Options
+}
+
+// ConvertAnswer turns a supplied answer into the option it names. The answer can be
+// the option's value, its index, or a core.OptionAnswer.
+func (s *Select) ConvertAnswer(value interface{}) (interface{}, error) {
+	if err := s.loadOptions(); err != nil {
+		return nil, err
+	}
+	ans, err := findChoice(s.Choices, s.labels(), value)
+	// an option that is loaded might only show up when filtering for it
+	if val, ok := value.(string); ok && err != nil && s.loader != nil {
+		if loadErr := s.loader.loadNow(val); loadErr != nil {
+			return nil, loadErr
+		}
+		return findChoice(s.Choices, s.labels(), value)
+	}
+	return ans, err
+}
+
+// findOption looks up the option with the given value or index.
+func findOption(options []string, value interface{}) (core.OptionAnswer, error) {
+	switch val := value.(type) {
+	case core.OptionAnswer:
+		return findOption(options, val.Value)
+	case string:
+		for i, option := range options {
+			if option == val {
+				return core.OptionAnswer{Value: option, Index: i}, nil
+			}
+		}
+		return core.OptionAnswer{}, fmt.Errorf("%q is not one of the options", val)
+	case int:
+		if val < 0 || val >= len(options) {
+			return core.OptionAnswer{}, fmt.Errorf("there is no option at index %d", val)
+		}
+		return core.OptionAnswer{Value: options[val], Index: val}, nil
+	}
+	return core.OptionAnswer{}, fmt.Errorf("cannot pick an option with a %T", value)
+}
+
+// prefill makes an earlier answer the default when going back to the question, or
+// goes back to Default when there is none.
+func (s *Select) prefill(ans interface{}) {
+	s.previous = nil
+	if val, ok := ans.(core.OptionAnswer); ok {
+		label := choiceLabel(s.Choices, val)
+		s.previous = &label
+	}
+}
+
+// prefilled is the prompt as the user sees it, with the earlier answer in place of
+// Default.
+func (s *Select) prefilled() Select {
+	prompt := *s
+	if s.previous != nil {
+		prompt.Default = *s.previous
+	}
+	return prompt
+}
+
+// formatAnswer returns the answer the way it is shown once the question is answered.
+func (s *Select) formatAnswer(val interface{}) string {
+	return choiceLabel(s.Choices, val.(core.OptionAnswer))
 }
 
 func (s *Select) Cleanup(config *PromptConfig, val interface{}) error {
@@ -320,7 +524,7 @@ func (s *Select) Cleanup(config *PromptConfig, val interface{}) error {
 		SelectQuestionTemplate,
 		SelectTemplateData{
 			Select:     *s,
-			Answer:     val.(core.OptionAnswer).Value,
+			Answer:     s.formatAnswer(val),
 			ShowAnswer: true,
 			Config:     config,
 		},
@@ -31,6 +31,19 @@ func TestSelectRender(t *testing.T) {
 	helpfulPrompt := prompt
 	helpfulPrompt.Help = "This is helpful"
 
+	choicePrompt := Select{
+		Message: "Pick a region:",
+		Choices: []Choice{
+			{Label: "US East", Value: "us-east-1", Description: "Virginia"},
+			{Label: "AP South", Disabled: true, DisabledReason: "not on your plan"},
+		},
+	}
+
+	groupPrompt := Select{
+		Message: "Pick a region:",
+		Choices: regionGroups(),
+	}
+
 	tests := []struct {
 		title    string
 		prompt   Select
@@ -89,6 +102,60 @@ func TestSelectRender(t *testing.T) {
 				"\n",
 			),
 		},
+		{
+			"Test Select question output with choices",
+			choicePrompt,
+			SelectTemplateData{Description: "Virginia", PageEntries: core.OptionAnswerList(choicePrompt.labels())},
+			strings.Join(
+				[]string{
+					fmt.Sprintf("%s Pick a region:  [Use arrows to move, type to filter]", defaultIcons().Question.Text),
+					fmt.Sprintf("%s US East", defaultIcons().SelectFocus.Text),
+					"  AP South (not on your plan)",
+					"Virginia\n",
+				},
+				"\n",
+			),
+		},
+		{
+			"Test Select question output with matches highlighted",
+			prompt,
+			SelectTemplateData{
+				SelectedIndex: 0,
+				PageEntries:   []core.OptionAnswer{{Value: "bar", Index: 1}, {Value: "baz", Index: 2}},
+				Highlights: map[int][]MatchSegment{
+					1: {{Text: "ba", Matched: true}, {Text: "r"}},
+					2: {{Text: "ba", Matched: true}, {Text: "z"}},
+				},
+			},
+			strings.Join(
+				[]string{
+					fmt.Sprintf("%s Pick your word:  [Use arrows to move, type to filter]", defaultIcons().Question.Text),
+					fmt.Sprintf("%s bar", defaultIcons().SelectFocus.Text),
+					"  baz\n",
+				},
+				"\n",
+			),
+		},
+		{
+			"Test Select question output with groups",
+			groupPrompt,
+			SelectTemplateData{
+				SelectedIndex: 1,
+				PageEntries:   core.OptionAnswerList(groupPrompt.labels()),
+				Headers:       map[int]string{0: "Cloud regions", 2: "On-prem"},
+			},
+			strings.Join(
+				[]string{
+					fmt.Sprintf("%s Pick a region:  [Use arrows to move, type to filter]", defaultIcons().Question.Text),
+					"Cloud regions",
+					"  US East",
+					fmt.Sprintf("%s EU West", defaultIcons().SelectFocus.Text),
+					"On-prem",
+					"  Frankfurt DC\n",
+				},
+				"\n",
+			),
+		},
 	}
 
 	for _, test := range tests {
@@ -258,6 +325,21 @@ func TestSelectPrompt(t *testing.T) {
 			},
 			core.OptionAnswer{Index: 2, Value: "green"},
 		},
+		{
+			"fuzzy matcher ranks the closest matches first",
+			&Select{
+				Message: "Choose a day:",
+				Options: []string{"Thursday", "Saturday", "Tuesday"},
+				Matcher: FuzzyMatch,
+			},
+			func(c *expect.Console) {
+				c.ExpectString("Choose a day:")
+				// Tuesday matches best so it moves to the top
+				c.SendLine("tu")
+				c.ExpectEOF()
+			},
+			core.OptionAnswer{Index: 2, Value: "Tuesday"},
+		},
 		{
 			"Can select the first result in a filtered list if there is a default",
 			&Select{
@@ -311,6 +393,26 @@ func TestSelectPrompt(t *testing.T) {
 			},
 			core.OptionAnswer{Index: 0, Value: "red"},
 		},
+		{
+			"choices skip disabled options",
+			&Select{
+				Message: "Pick a region:",
+				Choices: []Choice{
+					{Label: "AP South", Value: "ap-south-1", Disabled: true},
+					{Label: "US East", Value: "us-east-1", Description: "Virginia"},
+					{Label: "EU North", Value: "eu-north-1", Disabled: true},
+					{Label: "EU West", Value: "eu-west-1", Description: "Ireland"},
+				},
+			},
+			func(c *expect.Console) {
+				c.ExpectString("Virginia")
+				c.Send(string(terminal.KeyArrowDown))
+				c.ExpectString("Ireland")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			core.OptionAnswer{Index: 3, Value: "eu-west-1"},
+		},
 	}
 
 	for _, test := range tests {
@@ -319,3 +421,224 @@ func TestSelectPrompt(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectDefaultAnswer(t *testing.T) {
+	tests := []struct {
+		name     string
+		prompt   *Select
+		expected core.OptionAnswer
+	}{
+		{
+			"no default",
+			&Select{Options: []string{"red", "blue", "green"}},
+			core.OptionAnswer{Index: 0, Value: "red"},
+		},
+		{
+			"default value",
+			&Select{Options: []string{"red", "blue", "green"}, Default: "green"},
+			core.OptionAnswer{Index: 2, Value: "green"},
+		},
+		{
+			"default index",
+			&Select{Options: []string{"red", "blue", "green"}, Default: 1},
+			core.OptionAnswer{Index: 1, Value: "blue"},
+		},
+		{
+			"first choice that isn't disabled",
+			&Select{Choices: []Choice{{Label: "Red", Disabled: true}, {Label: "Blue", Value: "blue"}}},
+			core.OptionAnswer{Index: 1, Value: "blue"},
+		},
+		{
+			"loaded options",
+			&Select{Load: loadColors, Default: "blue"},
+			core.OptionAnswer{Index: 1, Value: "blue"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			answer, err := test.prompt.DefaultAnswer()
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, answer)
+		})
+	}
+}
+
+func TestAskOne_selectLoad(t *testing.T) {
+	tests := []struct {
+		name      string
+		prompt    *Select
+		procedure func(*expect.Console)
+		expected  core.OptionAnswer
+	}{
+		{
+			"loading options",
+			&Select{Message: "Choose a color:", Load: loadColors},
+			func(c *expect.Console) {
+				c.ExpectString("Loading...")
+				c.ExpectString("green")
+				// search for the colors that weren't loaded up front
+				c.Send("gr")
+				c.ExpectString("grey")
+				c.Send(string(terminal.KeyArrowDown))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			core.OptionAnswer{Index: 3, Value: "grey"},
+		},
+		{
+			"default value once the options load",
+			&Select{Message: "Choose a color:", Load: loadColors, Default: "blue"},
+			func(c *expect.Console) {
+				c.ExpectString("> blue")
+				c.Send(string(terminal.KeyArrowDown))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			core.OptionAnswer{Index: 2, Value: "green"},
+		},
+		{
+			"default index once the options load",
+			&Select{Message: "Choose a color:", Load: loadColors, Default: 2},
+			func(c *expect.Console) {
+				c.ExpectString("> green")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			core.OptionAnswer{Index: 2, Value: "green"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			answer := core.OptionAnswer{}
+			RunTest(t, test.procedure, func(stdio terminal.Stdio) error {
+				return AskOne(test.prompt, &answer, WithStdio(stdio.In, stdio.Out, stdio.Err))
+			})
+			assert.Equal(t, test.expected, answer)
+		})
+	}
+}
+
+func TestSelectPrompt_loadOutsideAsk(t *testing.T) {
+	prompt := &Select{Message: "Choose a color:", Load: loadColors}
+	_, err := prompt.Prompt(defaultPromptConfig())
+	assert.Equal(t, errLoadOutsideAsk, err)
+}
+
+func TestSelectConvertAnswer(t *testing.T) {
+	prompt := &Select{Options: []string{"red", "blue", "green"}}
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected core.OptionAnswer
+	}{
+		{"value", "green", core.OptionAnswer{Index: 2, Value: "green"}},
+		{"index", 1, core.OptionAnswer{Index: 1, Value: "blue"}},
+		{"option answer", core.OptionAnswer{Index: 0, Value: "red"}, core.OptionAnswer{Index: 0, Value: "red"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			answer, err := prompt.ConvertAnswer(test.value)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, answer)
+		})
+	}
+
+	for _, value := range []interface{}{"purple", 3, -1, 1.5} {
+		_, err := prompt.ConvertAnswer(value)
+		assert.NotNil(t, err, "converting %v", value)
+	}
+}
+
+func TestSelectConvertAnswer_load(t *testing.T) {
+	prompt := &Select{Load: loadColors}
+
+	// options that only come back when filtering for them are looked up
+	answer, err := prompt.ConvertAnswer("gold")
+	assert.Nil(t, err)
+	assert.Equal(t, core.OptionAnswer{Index: 3, Value: "gold"}, answer)
+
+	_, err = prompt.ConvertAnswer("purple")
+	assert.NotNil(t, err)
+}
+
+func TestSelectConvertAnswer_choices(t *testing.T) {
+	prompt := &Select{Choices: []Choice{
+		{Label: "US East", Value: "us-east-1"},
+		{Label: "AP South", Value: "ap-south-1", Disabled: true},
+	}}
+
+	for _, value := range []interface{}{"US East", "us-east-1", 0} {
+		answer, err := prompt.ConvertAnswer(value)
+		assert.Nil(t, err)
+		assert.Equal(t, core.OptionAnswer{Index: 0, Value: "us-east-1"}, answer)
+	}
+
+	_, err := prompt.ConvertAnswer("ap-south-1")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, `"AP South" is disabled`, err.Error())
+	}
+}
+
+func TestSelectPrefill(t *testing.T) {
+	prompt := &Select{Options: []string{"red", "green", "blue"}, Default: 1}
+
+	// going back starts from the earlier answer without changing the default
+	prompt.prefill(core.OptionAnswer{Index: 2, Value: "blue"})
+	answer, err := prompt.DefaultAnswer()
+	assert.Nil(t, err)
+	assert.Equal(t, core.OptionAnswer{Index: 2, Value: "blue"}, answer)
+	assert.Equal(t, 1, prompt.Default)
+
+	prompt.prefill(nil)
+	answer, err = prompt.DefaultAnswer()
+	assert.Nil(t, err)
+	assert.Equal(t, core.OptionAnswer{Index: 1, Value: "green"}, answer)
+}
+
+func regionGroups() []Choice {
+	return []Choice{
+		{Label: "US East", Group: "Cloud regions"},
+		{Label: "EU West", Group: "Cloud regions"},
+		{Label: "Frankfurt DC", Group: "On-prem"},
+	}
+}
+
+func TestSelectGroupHeaders(t *testing.T) {
+	prompt := &Select{Choices: regionGroups()}
+	config := defaultPromptConfig()
+
+	options := prompt.filterOptions(config)
+	assert.Equal(t, map[int]string{0: "Cloud regions", 2: "On-prem"}, groupHeaders(prompt.Choices, options))
+	// a page that starts in the middle of a group still shows its header
+	assert.Equal(t, map[int]string{0: "Cloud regions", 1: "On-prem"}, groupHeaders(prompt.Choices, options[1:]))
+
+	// groups without a match are left out
+	prompt.filter = "frank"
+	options = prompt.filterOptions(config)
+	assert.Equal(t, map[int]string{0: "On-prem"}, groupHeaders(prompt.Choices, options))
+}
+
+func TestSelectGroupHeaders_ranked(t *testing.T) {
+	prompt := &Select{
+		Choices: []Choice{
+			{Label: "Southeast Asia", Group: "Cloud regions"},
+			{Label: "East US", Group: "Cloud regions"},
+			{Label: "East DC", Group: "On-prem"},
+		},
+		Matcher: FuzzyMatch,
+	}
+	prompt.filter = "east"
+
+	// the closest matches come first within each group, but the groups stay in order
+	options := prompt.filterOptions(defaultPromptConfig())
+	assert.Equal(t, []core.OptionAnswer{
+		{Value: "East US", Index: 1},
+		{Value: "Southeast Asia", Index: 0},
+		{Value: "East DC", Index: 2},
+	}, options)
+	assert.Equal(t, map[int]string{0: "Cloud regions", 2: "On-prem"}, groupHeaders(prompt.Choices, options))
+}
@@ -0,0 +1,447 @@
+package survey
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2/core"
+)
+
+/*
+AskStruct asks a question for every exported field of the struct pointed to by v
+and writes the answers back to the fields. The questions are described with
+struct tags:
+
+	config := struct {
+		Name   string   `message:"What is your name?" required:"true" min:"2"`
+		Color  string   `message:"Favorite color?" options:"red,blue,green" default:"blue"`
+		Colors []string `message:"Other colors?" options:"red,blue,green"`
+		Token  string   `survey:"token" prompt:"password"`
+		Ready  bool     `help:"Whether to start right away"`
+		Debug  bool     `survey:"-"`
+	}{}
+
+	err := survey.AskStruct(&config)
+
+The survey tag names the question, and fields tagged with "-" are left alone. The
+message tag defaults to the name of the field. The kind of prompt is picked from the
+type of the field: bool fields are asked with a Confirm, slices with a MultiSelect, maps
+with a KeyValue, fields with options with a Select, fields with a mask with a
+MaskedInput and everything else with an Input. The prompt tag picks one instead, and
+can be "input", "number", "date", "password", "masked", "multiline", "editor",
+"keyvalue", "confirm", "select" or "multiselect". If a field already has a value, it
+is used as the default answer in place of the default tag.
+
+The min and max tags limit the length of strings, the value of numbers and the number
+of options picked from a MultiSelect. The layout tag is the layout times are typed in,
+which defaults to RFC 3339. Fields holding a struct, or a pointer to one, get
+a question for each of their own fields, named with a dotted path like "DB.Host".
+*/
+func AskStruct(v interface{}, opts ...AskOpt) error {
+	qs, err := structQuestions(v)
+	if err != nil {
+		return err
+	}
+
+	return Ask(qs, v, opts...)
+}
+
+// structQuestions builds a question for every exported field of the struct pointed to by v.
+func structQuestions(v interface{}) ([]*Question, error) {
+	target := reflect.ValueOf(v)
+	// make sure we are going to be able to write to the struct
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Struct {
+		return nil, errors.New("you must pass a pointer to a struct to AskStruct")
+	}
+
+	return appendStructQuestions([]*Question{}, "", target.Elem())
+}
+
+// appendStructQuestions adds a question for every field of the struct to the list,
+// prefixing their names with the path to the struct.
+func appendStructQuestions(qs []*Question, prefix string, elem reflect.Value) ([]*Question, error) {
+	for i := 0; i < elem.NumField(); i++ {
+		field := elem.Type().Field(i)
+		// skip the unexported fields and the ones we were told to leave alone
+		if (field.PkgPath != "" && !field.Anonymous) || field.Tag.Get("survey") == "-" {
+			continue
+		}
+		name := field.Tag.Get("survey")
+		if name == "" {
+			name = field.Name
+		}
+
+		// nested structs get a question for each of their own fields
+		if nested, ok := nestedStruct(field, elem.Field(i)); ok {
+			nestedPrefix := prefix + name + "."
+			// embedded fields are written as if they were part of the parent
+			if field.Anonymous {
+				nestedPrefix = prefix
+			}
+
+			var err error
+			qs, err = appendStructQuestions(qs, nestedPrefix, nested)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		// unexported embedded fields that aren't structs are left alone
+		if field.PkgPath != "" {
+			continue
+		}
+
+		q, err := fieldQuestion(prefix+name, field, elem.Field(i))
+		if err != nil {
+			return nil, err
+		}
+		qs = append(qs, q)
+	}
+
+	return qs, nil
+}
+
+// nestedStruct returns the struct held by the field if it should be asked for field
+// by field, which is any struct or pointer to one that isn't given a prompt.
+func nestedStruct(field reflect.StructField, value reflect.Value) (reflect.Value, bool) {
+	if field.Tag.Get("prompt") != "" {
+		return reflect.Value{}, false
+	}
+
+	// types that read themselves from text are answered in one go
+	if readsText(value.Type()) {
+		return reflect.Value{}, false
+	}
+
+	switch {
+	case value.Kind() == reflect.Struct:
+		return value, true
+	case value.Kind() == reflect.Ptr && value.Type().Elem().Kind() == reflect.Struct:
+		// start from the zero value for structs that haven't been allocated yet
+		if value.IsNil() {
+			return reflect.New(value.Type().Elem()).Elem(), true
+		}
+		return value.Elem(), true
+	}
+
+	return reflect.Value{}, false
+}
+
+// fieldQuestion builds the question with the given name for a single field of a struct.
+func fieldQuestion(name string, field reflect.StructField, value reflect.Value) (*Question, error) {
+	message := field.Tag.Get("message")
+	if message == "" {
+		message = field.Name
+	}
+	help := field.Tag.Get("help")
+
+	options := []string{}
+	if tag := field.Tag.Get("options"); tag != "" {
+		for _, option := range strings.Split(tag, ",") {
+			options = append(options, strings.TrimSpace(option))
+		}
+	}
+
+	// pointers are asked for like the values they point to
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value = reflect.Zero(value.Type().Elem())
+		} else {
+			value = value.Elem()
+		}
+	}
+
+	// the answer to start from
+	var dflt interface{}
+	if !isZero(value) {
+		dflt = value.Interface()
+	} else if tag, ok := field.Tag.Lookup("default"); ok {
+		dflt = tag
+	}
+
+	kind := field.Tag.Get("prompt")
+	mask := field.Tag.Get("mask")
+	layout := field.Tag.Get("layout")
+	if kind == "" && mask != "" {
+		kind = "masked"
+	}
+	if kind == "" {
+		kind = promptKind(value.Type(), options)
+	}
+
+	// the limits on the answer
+	limits := map[string]float64{}
+	for _, bound := range []string{"min", "max"} {
+		tag, ok := field.Tag.Lookup(bound)
+		if !ok {
+			continue
+		}
+		limit, err := strconv.ParseFloat(tag, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s for %s: %v", bound, field.Name, err)
+		}
+		limits[bound] = limit
+	}
+
+	q := &Question{Name: name}
+	switch kind {
+	case "input":
+		prompt := &Input{Message: message, Help: help}
+		if dflt != nil {
+			prompt.Default = defaultText(dflt, layout)
+		}
+		q.Prompt = prompt
+	case "number":
+		prompt := &Number{Message: message, Help: help}
+		prompt.Float = value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64
+		var number float64
+		switch val := reflect.ValueOf(dflt); val.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			number = float64(val.Int())
+			prompt.Default = &number
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			number = float64(val.Uint())
+			prompt.Default = &number
+		case reflect.Float32, reflect.Float64:
+			number = val.Float()
+			prompt.Default = &number
+		case reflect.String:
+			parsed, err := strconv.ParseFloat(val.String(), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid default for %s: %v", field.Name, err)
+			}
+			number = parsed
+			prompt.Default = &number
+		}
+		// the prompt keeps the answer within the limits
+		if min, ok := limits["min"]; ok {
+			prompt.Min = &min
+		}
+		if max, ok := limits["max"]; ok {
+			prompt.Max = &max
+		}
+		q.Prompt = prompt
+	case "date":
+		prompt := &Date{Message: message, Help: help, Layout: layout}
+		switch val := dflt.(type) {
+		case time.Time:
+			prompt.Default = val
+		case string:
+			date, err := prompt.ConvertAnswer(val)
+			if err != nil {
+				return nil, fmt.Errorf("invalid default for %s: %v", field.Name, err)
+			}
+			prompt.Default = date.(time.Time)
+		}
+		q.Prompt = prompt
+	case "password":
+		q.Prompt = &Password{Message: message, Help: help}
+	case "masked":
+		prompt := &MaskedInput{Message: message, Help: help, Mask: mask}
+		if dflt != nil {
+			prompt.Default = defaultText(dflt, layout)
+		}
+		q.Prompt = prompt
+	case "multiline":
+		prompt := &Multiline{Message: message, Help: help}
+		if dflt != nil {
+			prompt.Default = fmt.Sprint(dflt)
+		}
+		q.Prompt = prompt
+	case "editor":
+		prompt := &Editor{Message: message, Help: help}
+		if dflt != nil {
+			prompt.Default = fmt.Sprint(dflt)
+		}
+		q.Prompt = prompt
+	case "keyvalue":
+		prompt := &KeyValue{Message: message, Help: help}
+		switch val := reflect.ValueOf(dflt); val.Kind() {
+		case reflect.Map:
+			prompt.Default = map[string]string{}
+			iter := val.MapRange()
+			for iter.Next() {
+				prompt.Default[iter.Key().String()] = fmt.Sprint(iter.Value().Interface())
+			}
+		case reflect.String:
+			pairs, err := prompt.ConvertAnswer(val.String())
+			if err != nil {
+				return nil, fmt.Errorf("invalid default for %s: %v", field.Name, err)
+			}
+			prompt.Default = pairs.(map[string]string)
+		}
+		q.Prompt = prompt
+	case "confirm":
+		prompt := &Confirm{Message: message, Help: help}
+		switch val := dflt.(type) {
+		case bool:
+			prompt.Default = val
+		case string:
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return nil, fmt.Errorf("invalid default for %s: %v", field.Name, err)
+			}
+			prompt.Default = b
+		}
+		q.Prompt = prompt
+	case "select":
+		if len(options) == 0 {
+			return nil, fmt.Errorf("%s needs options to select from", field.Name)
+		}
+		prompt := &Select{Message: message, Help: help, Options: options}
+		if dflt != nil {
+			// only start from answers that are still one of the options
+			if ans, err := findOption(options, selectDefault(dflt)); err == nil {
+				prompt.Default = ans.Value
+			}
+		}
+		q.Prompt = prompt
+	case "multiselect":
+		if len(options) == 0 {
+			return nil, fmt.Errorf("%s needs options to select from", field.Name)
+		}
+		prompt := &MultiSelect{Message: message, Help: help, Options: options}
+		switch val := dflt.(type) {
+		case []string, []int:
+			prompt.Default = val
+		case string:
+			prompt.Default = strings.Split(val, ",")
+		}
+		q.Prompt = prompt
+	default:
+		if kind == "" {
+			return nil, fmt.Errorf("cannot ask for %s, fields of type %s are not supported", field.Name, field.Type)
+		}
+		return nil, fmt.Errorf("unknown prompt %q for %s", kind, field.Name)
+	}
+
+	validators := []Validator{}
+	if field.Tag.Get("required") == "true" {
+		validators = append(validators, Required)
+	}
+	// check the limits in the same order every time
+	for _, bound := range []string{"min", "max"} {
+		if limit, ok := limits[bound]; ok {
+			validators = append(validators, limitValidator(bound, limit, value.Kind()))
+		}
+	}
+	if len(validators) > 0 {
+		q.Validate = ComposeValidators(validators...)
+	}
+
+	return q, nil
+}
+
+// promptKind picks the prompt to ask for a field of the given type with.
+func promptKind(t reflect.Type, options []string) string {
+	// types that read themselves from text can be typed in
+	if readsText(t) {
+		if len(options) > 0 {
+			return "select"
+		}
+		return "input"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "confirm"
+	case reflect.Slice, reflect.Array:
+		return "multiselect"
+	case reflect.Map:
+		if t.Key().Kind() == reflect.String {
+			return "keyvalue"
+		}
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if len(options) > 0 {
+			return "select"
+		}
+		return "input"
+	}
+	return ""
+}
+
+// readsText returns true if the type, or the type it points to, can read itself from
+// text like time.Time or net.IP.
+func readsText(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return reflect.PtrTo(t).Implements(textUnmarshalerType)
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// defaultText formats the value of a field the way the user would type it, which for
+// times is the layout of the field.
+func defaultText(dflt interface{}, layout string) string {
+	switch val := dflt.(type) {
+	case time.Time:
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		return val.Format(layout)
+	case encoding.TextMarshaler:
+		if text, err := val.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	return fmt.Sprint(dflt)
+}
+
+// selectDefault turns the value of a field into the option it refers to, which is
+// the option's index for numbers.
+func selectDefault(dflt interface{}) interface{} {
+	value := reflect.ValueOf(dflt)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(value.Uint())
+	}
+	return dflt
+}
+
+// limitValidator checks an answer against the min or max tag of a field.
+func limitValidator(bound string, limit float64, kind reflect.Kind) Validator {
+	return func(val interface{}) error {
+		var size float64
+		var what string
+		switch ans := val.(type) {
+		case []core.OptionAnswer:
+			size, what = float64(len(ans)), "number of options picked"
+		case int64:
+			size, what = float64(ans), "value"
+		case float64:
+			size, what = ans, "value"
+		case string:
+			// the answers to numeric fields are written as text
+			if kind != reflect.String {
+				n, err := strconv.ParseFloat(ans, 64)
+				if err != nil {
+					return fmt.Errorf("%q is not a number", ans)
+				}
+				size, what = n, "value"
+			} else {
+				size, what = float64(len([]rune(ans))), "length"
+			}
+		default:
+			return nil
+		}
+
+		if bound == "min" && size < limit {
+			return fmt.Errorf("%s is too small. Min is %v", what, limit)
+		}
+		if bound == "max" && size > limit {
+			return fmt.Errorf("%s is too large. Max is %v", what, limit)
+		}
+		return nil
+	}
+}
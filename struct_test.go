@@ -0,0 +1,243 @@
+package survey
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructQuestions(t *testing.T) {
+	config := struct {
+		Name   string   `message:"What is your name?" help:"Your full name"`
+		Color  string   `message:"Favorite color?" options:"red,blue,green" default:"blue"`
+		Shade  int      `options:"light,dark"`
+		Colors []string `options:"red, blue, green" default:"red,green"`
+		Token  string   `survey:"token" prompt:"password"`
+		Ready  bool     `default:"true"`
+		Port   int
+		Debug  bool `survey:"-"`
+		secret string
+	}{Name: "Larry Bird", Shade: 1, Port: 8080}
+
+	qs, err := structQuestions(&config)
+	require.Nil(t, err)
+
+	names := []string{}
+	for _, q := range qs {
+		names = append(names, q.Name)
+	}
+	assert.Equal(t, []string{"Name", "Color", "Shade", "Colors", "token", "Ready", "Port"}, names)
+
+	assert.Equal(t, &Input{Message: "What is your name?", Help: "Your full name", Default: "Larry Bird"}, qs[0].Prompt)
+	assert.Equal(t, &Select{Message: "Favorite color?", Options: []string{"red", "blue", "green"}, Default: "blue"}, qs[1].Prompt)
+	assert.Equal(t, &Select{Message: "Shade", Options: []string{"light", "dark"}, Default: "dark"}, qs[2].Prompt)
+	assert.Equal(t, &MultiSelect{Message: "Colors", Options: []string{"red", "blue", "green"}, Default: []string{"red", "green"}}, qs[3].Prompt)
+	assert.IsType(t, &Password{}, qs[4].Prompt)
+	assert.Equal(t, &Confirm{Message: "Ready", Default: true}, qs[5].Prompt)
+	assert.Equal(t, &Input{Message: "Port", Default: "8080"}, qs[6].Prompt)
+}
+
+func TestStructQuestions_validators(t *testing.T) {
+	config := struct {
+		Name   string   `required:"true" min:"2" max:"5"`
+		Port   int      `min:"1" max:"65535"`
+		Colors []string `options:"red,blue,green" min:"1"`
+	}{}
+
+	qs, err := structQuestions(&config)
+	require.Nil(t, err)
+
+	assert.NotNil(t, qs[0].Validate(""))
+	assert.NotNil(t, qs[0].Validate("a"))
+	assert.NotNil(t, qs[0].Validate("abcdef"))
+	assert.Nil(t, qs[0].Validate("abc"))
+
+	assert.NotNil(t, qs[1].Validate("0"))
+	assert.NotNil(t, qs[1].Validate("70000"))
+	assert.NotNil(t, qs[1].Validate("http"))
+	assert.Nil(t, qs[1].Validate("8080"))
+
+	assert.NotNil(t, qs[2].Validate([]OptionAnswer{}))
+	assert.Nil(t, qs[2].Validate([]OptionAnswer{{Value: "red", Index: 0}}))
+}
+
+func TestStructQuestions_errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		target interface{}
+	}{
+		{"not a pointer", struct{ Name string }{}},
+		{"not a struct", new(string)},
+		{"unsupported type", &struct{ Names map[int]string }{}},
+		{"unknown prompt", &struct {
+			Name string `prompt:"slider"`
+		}{}},
+		{"select without options", &struct {
+			Name string `prompt:"select"`
+		}{}},
+		{"invalid limit", &struct {
+			Name string `min:"two"`
+		}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := structQuestions(test.target)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestAskStruct(t *testing.T) {
+	in, out, closeStdio := pipeStdio(t)
+	defer closeStdio()
+
+	config := struct {
+		Name   string   `message:"What is your name?"`
+		Color  string   `options:"red,blue,green" default:"blue"`
+		Colors []string `options:"red,blue,green"`
+		Ready  bool
+		Port   int `default:"8080"`
+	}{}
+
+	err := AskStruct(
+		&config,
+		WithStdio(in, out, out),
+		WithAnswers(map[string]interface{}{
+			"Name":   "Larry Bird",
+			"Colors": []string{"green"},
+			"Ready":  "yes",
+		}),
+	)
+	require.Nil(t, err)
+
+	assert.Equal(t, "Larry Bird", config.Name)
+	assert.Equal(t, "blue", config.Color)
+	assert.Equal(t, []string{"green"}, config.Colors)
+	assert.True(t, config.Ready)
+	assert.Equal(t, 8080, config.Port)
+}
+
+func TestAskStruct_nested(t *testing.T) {
+	in, out, closeStdio := pipeStdio(t)
+	defer closeStdio()
+
+	type Auth struct {
+		User string `default:"admin"`
+	}
+	type Base struct {
+		Name string
+	}
+	config := struct {
+		Base
+		DB struct {
+			Host string `survey:"host" default:"localhost"`
+			Auth *Auth
+		} `survey:"db"`
+	}{}
+
+	qs, err := structQuestions(&config)
+	require.Nil(t, err)
+	names := []string{}
+	for _, q := range qs {
+		names = append(names, q.Name)
+	}
+	assert.Equal(t, []string{"Name", "db.host", "db.Auth.User"}, names)
+
+	err = AskStruct(
+		&config,
+		WithStdio(in, out, out),
+		WithAnswers(map[string]interface{}{"Name": "Larry Bird"}),
+	)
+	require.Nil(t, err)
+
+	assert.Equal(t, "Larry Bird", config.Name)
+	assert.Equal(t, "localhost", config.DB.Host)
+	if assert.NotNil(t, config.DB.Auth) {
+		assert.Equal(t, "admin", config.DB.Auth.User)
+	}
+}
+
+func TestStructQuestions_textFields(t *testing.T) {
+	start := time.Date(2019, 6, 1, 10, 30, 0, 0, time.UTC)
+	port := 8080
+	config := struct {
+		Start time.Time
+		IP    net.IP
+		Port  *int
+		Debug *bool
+		End   time.Time `layout:"2006-01-02"`
+	}{Start: start, IP: net.ParseIP("127.0.0.1"), Port: &port, End: start}
+
+	qs, err := structQuestions(&config)
+	require.Nil(t, err)
+
+	assert.Equal(t, &Input{Message: "Start", Default: "2019-06-01T10:30:00Z"}, qs[0].Prompt)
+	assert.Equal(t, &Input{Message: "IP", Default: "127.0.0.1"}, qs[1].Prompt)
+	assert.Equal(t, &Input{Message: "Port", Default: "8080"}, qs[2].Prompt)
+	assert.Equal(t, &Confirm{Message: "Debug"}, qs[3].Prompt)
+	assert.Equal(t, &Input{Message: "End", Default: "2019-06-01"}, qs[4].Prompt)
+}
+
+func TestStructQuestions_number(t *testing.T) {
+	config := struct {
+		Port  int     `prompt:"number" min:"1" max:"65535"`
+		Ratio float64 `prompt:"number" default:"0.5"`
+		Tries int     `prompt:"number" min:"0"`
+	}{Port: 8080}
+
+	qs, err := structQuestions(&config)
+	require.Nil(t, err)
+
+	assert.Equal(t, &Number{Message: "Port", Default: num(8080), Min: num(1), Max: num(65535)}, qs[0].Prompt)
+	assert.Equal(t, &Number{Message: "Ratio", Default: num(0.5), Float: true}, qs[1].Prompt)
+	assert.Equal(t, &Number{Message: "Tries", Min: num(0)}, qs[2].Prompt)
+
+	assert.NotNil(t, qs[0].Validate(int64(0)))
+	assert.Nil(t, qs[0].Validate(int64(80)))
+}
+
+func TestStructQuestions_date(t *testing.T) {
+	start := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.Local)
+	config := struct {
+		Start time.Time `prompt:"date"`
+		End   time.Time `prompt:"date" default:"2026-10-31"`
+		Due   time.Time `prompt:"date" layout:"02/01/2006" default:"01/12/2026"`
+	}{Start: start}
+
+	qs, err := structQuestions(&config)
+	require.Nil(t, err)
+
+	assert.Equal(t, &Date{Message: "Start", Default: start}, qs[0].Prompt)
+	assert.Equal(t, &Date{Message: "End", Default: time.Date(2026, time.October, 31, 0, 0, 0, 0, time.Local)}, qs[1].Prompt)
+	assert.Equal(t, &Date{Message: "Due", Layout: "02/01/2006", Default: time.Date(2026, time.December, 1, 0, 0, 0, 0, time.Local)}, qs[2].Prompt)
+}
+
+func TestStructQuestions_masked(t *testing.T) {
+	config := struct {
+		Phone string `mask:"(###) ###-####"`
+		IP    string `prompt:"masked" mask:"999.999.999.999" default:"10.0.0.1"`
+	}{}
+
+	qs, err := structQuestions(&config)
+	require.Nil(t, err)
+
+	assert.Equal(t, &MaskedInput{Message: "Phone", Mask: "(###) ###-####"}, qs[0].Prompt)
+	assert.Equal(t, &MaskedInput{Message: "IP", Mask: "999.999.999.999", Default: "10.0.0.1"}, qs[1].Prompt)
+}
+
+func TestStructQuestions_keyValue(t *testing.T) {
+	config := struct {
+		Labels map[string]string `default:"team=search,env=prod"`
+		Limits map[string]int
+	}{Limits: map[string]int{"cpu": 2}}
+
+	qs, err := structQuestions(&config)
+	require.Nil(t, err)
+
+	assert.Equal(t, &KeyValue{Message: "Labels", Default: map[string]string{"team": "search", "env": "prod"}}, qs[0].Prompt)
+	assert.Equal(t, &KeyValue{Message: "Limits", Default: map[string]string{"cpu": "2"}}, qs[1].Prompt)
+}
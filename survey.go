@@ -1,15 +1,27 @@
 package survey
 
 import (
+	"context"
 	"errors"
 	"io"
 	"os"
-	"strings"
+	"reflect"
+	"sort"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2/core"
 	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/mattn/go-isatty"
 )
 
+// ErrNoTTY is returned when a question has to be asked but the input is not a terminal
+// and there is neither a supplied answer nor a default to fall back on.
+var ErrNoTTY = errors.New("cannot prompt for an answer without a terminal")
+
+// ErrNoDefault is returned by a Defaulter that has no default answer to give, like an
+// Input without a Default.
+var ErrNoDefault = errors.New("the prompt has no default answer")
+
 // DefaultAskOptions is the default options on ask, using the OS stdio.
 func defaultAskOptions() *AskOptions {
 	return &AskOptions{
@@ -46,13 +58,16 @@ func defaultAskOptions() *AskOptions {
 					Text:   ">",
 					Format: "cyan+b",
 				},
+				ExpandedOption: Icon{
+					Text:   "-",
+					Format: "default+hb",
+				},
+				CollapsedOption: Icon{
+					Text:   "+",
+					Format: "default+hb",
+				},
 			},
-			Filter: func(filter string, value string, index int) (include bool) {
-				filter = strings.ToLower(filter)
-
-				// include this option if it matches
-				return strings.Contains(strings.ToLower(value), filter)
-			},
+			Filter: containsFilter,
 		},
 	}
 }
@@ -75,13 +90,15 @@ type Icon struct {
 
 // IconSet holds the icons to use for various prompts
 type IconSet struct {
-	HelpInput      Icon
-	Error          Icon
-	Help           Icon
-	Question       Icon
-	MarkedOption   Icon
-	UnmarkedOption Icon
-	SelectFocus    Icon
+	HelpInput       Icon
+	Error           Icon
+	Help            Icon
+	Question        Icon
+	MarkedOption    Icon
+	UnmarkedOption  Icon
+	SelectFocus     Icon
+	ExpandedOption  Icon
+	CollapsedOption Icon
 }
 
 // Validator is a function passed to a Question after a user has provided a response.
@@ -96,12 +113,19 @@ type Validator func(ans interface{}) error
 // Look `TransformString`, `ToLower` `Title` and `ComposeTransformers` for more.
 type Transformer func(ans interface{}) (newAns interface{})
 
-// Question is the core data structure for a survey questionnaire.
+// Question is the core data structure for a survey questionnaire. If Timeout is set
+// and the user does not answer in time, the prompt's default answer is used instead.
+// If When is set, the question is only asked when it returns true for the answers
+// collected so far, keyed by question name. On a Windows console the timeout only
+// takes effect once the user presses a key, since keys are read straight from the
+// console and that read can't be interrupted.
 type Question struct {
 	Name      string
 	Prompt    Prompt
 	Validate  Validator
 	Transform Transformer
+	Timeout   time.Duration
+	When      func(answers map[string]interface{}) bool
 }
 
 // PromptConfig holds the global configuration for a prompt
@@ -110,6 +134,7 @@ type PromptConfig struct {
 	Icons     IconSet
 	HelpInput string
 	Filter    func(filter string, option string, index int) bool
+	Matcher   Matcher
 }
 
 // Prompt is the primary interface for the objects that can take user input
@@ -120,6 +145,20 @@ type Prompt interface {
 	Error(*PromptConfig, error) error
 }
 
+// Defaulter is implemented by prompts that can produce their default answer without
+// waiting for the user, which is needed when a question times out. Prompts that were
+// not given a default return ErrNoDefault.
+type Defaulter interface {
+	DefaultAnswer() (interface{}, error)
+}
+
+// AnswerConverter is implemented by prompts that can turn an answer supplied with
+// WithAnswers into the same kind of value they return from Prompt. Answers to
+// prompts that don't implement it are used as they are.
+type AnswerConverter interface {
+	ConvertAnswer(value interface{}) (interface{}, error)
+}
+
 // PromptAgainer Interface for Prompts that support prompting again after invalid input
 type PromptAgainer interface {
 	PromptAgain(config *PromptConfig, invalid interface{}, err error) (interface{}, error)
@@ -133,6 +172,11 @@ type AskOptions struct {
 	Stdio        terminal.Stdio
 	Validators   []Validator
 	PromptConfig PromptConfig
+	Timeout      time.Duration
+	Review       bool
+	Answers      map[string]interface{}
+	Replay       map[string]interface{}
+	Record       string
 }
 
 // WithStdio specifies the standard input, output and error files survey
@@ -146,11 +190,25 @@ func WithStdio(in terminal.FileReader, out terminal.FileWriter, err io.Writer) A
 	}
 }
 
-// WithFilter specifies the default filter to use when asking questions.
+// WithFilter specifies the default filter to use when asking questions. The options that
+// pass the filter are shown in the order they were given.
 func WithFilter(filter func(filter string, value string, index int) (include bool)) AskOpt {
 	return func(options *AskOptions) error {
 		// save the filter internally
 		options.PromptConfig.Filter = filter
+		// and use it in place of the default matcher
+		options.PromptConfig.Matcher = nil
+
+		return nil
+	}
+}
+
+// WithMatcher specifies the default matcher to use when asking questions, which ranks
+// the options that match the filter and says which of their runes to highlight.
+func WithMatcher(matcher Matcher) AskOpt {
+	return func(options *AskOptions) error {
+		// save the matcher internally
+		options.PromptConfig.Matcher = matcher
 
 		return nil
 	}
@@ -167,10 +225,105 @@ func WithValidator(v Validator) AskOpt {
 	}
 }
 
+// WithTimeout gives the user a limited amount of time to answer each question, after which
+// the prompt's default answer is used. A Timeout set on the question itself takes precedence.
+// Like AskContext, keys are read through a goroutine that is still waiting on the input once
+// the survey is over, so after the last question times out the next line typed into stdin
+// may be consumed by it. The time left is not shown, so mention the timeout in the message
+// if the user should know about it.
+func WithTimeout(timeout time.Duration) AskOpt {
+	return func(options *AskOptions) error {
+		// save the timeout internally
+		options.Timeout = timeout
+
+		// nothing went wrong
+		return nil
+	}
+}
+
+// WithReview shows a summary of every answer after the last question, letting the
+// user accept them or pick one to answer again.
+func WithReview() AskOpt {
+	return func(options *AskOptions) error {
+		// turn on the review
+		options.Review = true
+
+		// nothing went wrong
+		return nil
+	}
+}
+
+// WithAnswers answers the questions with a matching name without prompting the user.
+// The answers still have to pass the question's validators and are transformed as
+// usual. Calling it more than once adds to the answers given before.
+func WithAnswers(answers map[string]interface{}) AskOpt {
+	return func(options *AskOptions) error {
+		if options.Answers == nil {
+			options.Answers = map[string]interface{}{}
+		}
+		// save the answers internally
+		for name, ans := range answers {
+			options.Answers[name] = ans
+		}
+
+		// nothing went wrong
+		return nil
+	}
+}
+
+// WithRecord saves the answer to every question in a JSON file at the given path when
+// Ask returns, so the same answers can be given again with WithReplay. Passwords are
+// not saved and the file is only readable by the user that created it.
+func WithRecord(path string) AskOpt {
+	return func(options *AskOptions) error {
+		// save the path internally
+		options.Record = path
+
+		// nothing went wrong
+		return nil
+	}
+}
+
+// WithReplay answers the questions with the answers recorded in the given file by
+// WithRecord. Questions without a recorded answer are asked as usual. The answers
+// still have to pass the question's validators; if any don't, Ask goes through the
+// remaining questions and returns a ReplayError naming every answer that failed.
+func WithReplay(path string) AskOpt {
+	return func(options *AskOptions) error {
+		// read the recorded answers
+		answers, err := readAnswers(path)
+		if err != nil {
+			return err
+		}
+
+		// save them internally
+		options.Replay = answers
+
+		// nothing went wrong
+		return nil
+	}
+}
+
 type wantsStdio interface {
 	WithStdio(terminal.Stdio)
 }
 
+type wantsClear interface {
+	clear()
+}
+
+type wantsForget interface {
+	forget()
+}
+
+type wantsPrefill interface {
+	prefill(ans interface{})
+}
+
+type formatsAnswer interface {
+	formatAnswer(ans interface{}) string
+}
+
 // WithPageSize sets the default page size used by prompts
 func WithPageSize(pageSize int) AskOpt {
 	return func(options *AskOptions) error {
@@ -218,7 +371,13 @@ in the documentation. For example:
 
 */
 func AskOne(p Prompt, response interface{}, opts ...AskOpt) error {
-	err := Ask([]*Question{{Prompt: p}}, response, opts...)
+	return AskOneContext(context.Background(), p, response, opts...)
+}
+
+// AskOneContext is like AskOne but gives up on the prompt as soon as the context
+// is done, returning ctx.Err().
+func AskOneContext(ctx context.Context, p Prompt, response interface{}, opts ...AskOpt) error {
+	err := AskContext(ctx, []*Question{{Prompt: p}}, response, opts...)
 	if err != nil {
 		return err
 	}
@@ -232,8 +391,8 @@ type can be one of two options. If a struct is passed, the answer will be writte
 the field whose name matches the Name field on the corresponding question. Field types
 should be something that can be casted from the response type designated in the
 documentation. Note, a survey tag can also be used to identify a Otherwise, a
-map[string]interface{} can be passed, responses will be written to the key with the
-matching name. For example:
+map with string keys like a map[string]interface{} can be passed, responses will be
+written to the key with the matching name. For example:
 
 	qs := []*survey.Question{
 		{
@@ -250,6 +409,28 @@ matching name. For example:
 	err := survey.Ask(qs, &answers)
 */
 func Ask(qs []*Question, response interface{}, opts ...AskOpt) error {
+	return AskContext(context.Background(), qs, response, opts...)
+}
+
+/*
+AskContext is like Ask but stops waiting on the user as soon as the context is
+done. The terminal is restored, whatever the current prompt had rendered is cleared
+and ctx.Err() is returned. Answers to the questions before the current one will
+have already been written to the response. For example:
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	err := survey.AskContext(ctx, qs, &answers)
+	if err == context.DeadlineExceeded {
+		fmt.Println("took too long to answer")
+	}
+
+Cancellation relies on reading the input through a goroutine, so the user's
+keystrokes after a cancellation may be discarded. Prompts reading from a Windows
+console are not interrupted.
+*/
+func AskContext(ctx context.Context, qs []*Question, response interface{}, opts ...AskOpt) (err error) {
 	// build up the configuration options
 	options := defaultAskOptions()
 	for _, opt := range opts {
@@ -267,86 +448,507 @@ func Ask(qs []*Question, response interface{}, opts ...AskOpt) error {
 		return errors.New("cannot call Ask() with a nil reference to record the answers")
 	}
 
-	// go over every question
-	for _, q := range qs {
-		// If Prompt implements controllable stdio, pass in specified stdio.
-		if p, ok := q.Prompt.(wantsStdio); ok {
-			p.WithStdio(options.Stdio)
-		}
+	// without a terminal there is nobody to prompt
+	interactive := isTerminal(options.Stdio.In)
 
-		// grab the user input and save it
-		ans, err := q.Prompt.Prompt(&options.PromptConfig)
-		// if there was a problem
-		if err != nil {
-			return err
-		}
+	// if the context can be cancelled, a question can time out or options load in the
+	// background we need to be able to walk away from a blocked read
+	var reader *cancelableReader
+	if interactive && (ctx.Done() != nil || hasTimeout(qs, options) || hasLoader(qs)) {
+		reader = newCancelableReader(ctx, options.Stdio.In)
+		options.Stdio.In = reader
+	}
 
-		// build up a list of validators that we have to apply to this question
-		validators := []Validator{}
+	// the answers so far, for deciding which questions to ask
+	answers := map[string]interface{}{}
+	// the answers as the prompts returned them, for revisiting a question
+	raws := make([]interface{}, len(qs))
+	// the questions the user had to answer themselves
+	asked := make([]bool, len(qs))
+	// the questions that have been answered, in the order they come in
+	history := []int{}
+	// the replayed answers that are no longer valid
+	invalid := ReplayError{}
+	// what the response held before each question was answered, for taking back the
+	// answers to questions the user goes back past
+	previous := map[int]core.PreviousAnswer{}
+
+	// save the answers for replaying later, even if something went wrong along the way
+	if options.Record != "" {
+		defer func() {
+			if recordErr := recordAnswers(options.Record, qs, history, raws); err == nil {
+				err = recordErr
+			}
+		}()
+	}
 
-		// make sure to include the question specific one
-		if q.Validate != nil {
-			validators = append(validators, q.Validate)
-		}
-		// add any "global" validators
-		for _, validator := range options.Validators {
-			validators = append(validators, validator)
-		}
+	// go over every question, and over the ones after an answer the user changed while
+	// reviewing them, since the new answer can change which of them apply
+	for i := 0; ; {
+		for i < len(qs) {
+			q := qs[i]
+
+			// skip the questions that don't apply given the earlier answers
+			if q.When != nil && !q.When(answers) {
+				// a changed answer can rule out a question that was already answered
+				if at := answeredAt(history, i); at >= 0 {
+					delete(answers, q.Name)
+					forgetAnswers(previous, []int{i})
+					history = append(history[:at], history[at+1:]...)
+				}
+				i++
+				continue
+			}
+			// the questions that still apply keep their answers
+			if answeredAt(history, i) >= 0 {
+				i++
+				continue
+			}
+
+			// If Prompt implements controllable stdio, pass in specified stdio.
+			if p, ok := q.Prompt.(wantsStdio); ok {
+				p.WithStdio(options.Stdio)
+			}
 
-		// apply every validator to thte response
-		for _, validator := range validators {
-			// wait for a valid response
-			for invalid := validator(ans); invalid != nil; invalid = validator(ans) {
-				err := q.Prompt.Error(&options.PromptConfig, invalid)
+			// questions we already have an answer for don't need the user
+			supplied, ok := options.Answers[q.Name]
+			replayed := false
+			if !ok {
+				supplied, ok = options.Replay[q.Name]
+				replayed = ok
+			}
+			if ok || !interactive {
+				var raw interface{}
+				var err error
+				if ok {
+					raw, err = suppliedAnswer(q, options, supplied)
+				} else {
+					raw, err = askDefault(q, options, ErrNoTTY)
+				}
+				// keep going so every invalid replayed answer can be reported at once
+				if err != nil && replayed {
+					invalid[q.Name] = err
+					i++
+					continue
+				}
 				// if there was a problem
 				if err != nil {
 					return err
 				}
 
-				// ask for more input
-				if promptAgainer, ok := q.Prompt.(PromptAgainer); ok {
-					ans, err = promptAgainer.PromptAgain(&options.PromptConfig, ans, invalid)
-				} else {
-					ans, err = q.Prompt.Prompt(&options.PromptConfig)
-				}
-				// if there was a problem
+				rememberAnswer(previous, response, qs, i)
+				ans, err := writeAnswer(q, response, raw)
+				// if something went wrong
 				if err != nil {
 					return err
 				}
+
+				answers[q.Name] = ans
+				raws[i] = raw
+				history = markAnswered(history, i)
+				i++
+				continue
 			}
-		}
 
-		if q.Transform != nil {
-			// check if we have a transformer available, if so
-			// then try to acquire the new representation of the
-			// answer, if the resulting answer is not nil.
-			if newAns := q.Transform(ans); newAns != nil {
-				ans = newAns
+			// if the user went back past the question, start from the answer they gave last time
+			if p, ok := q.Prompt.(wantsPrefill); ok && raws[i] != nil {
+				p.prefill(raws[i])
 			}
+			raw, err := askQuestion(ctx, reader, q, options, response)
+			// if the user wants to revisit the previous question
+			if err == terminal.GoBackErr {
+				if p, ok := q.Prompt.(wantsClear); ok {
+					p.clear()
+				}
+
+				// find the last question before this one the user answered themselves
+				last := sort.SearchInts(history, i) - 1
+				for last >= 0 && !asked[history[last]] {
+					last--
+				}
+				// if there is nothing to go back to, just ask again
+				if last < 0 {
+					continue
+				}
+
+				// forget every answer from there on. The questions after it might not be
+				// asked again, so take their answers back out of the response as well
+				for _, j := range history[last:] {
+					delete(answers, qs[j].Name)
+				}
+				forgetAnswers(previous, history[last:])
+				i = history[last]
+				history = history[:last]
+				continue
+			}
+			// if there was a problem
+			if err != nil {
+				return err
+			}
+
+			rememberAnswer(previous, response, qs, i)
+			ans, err := saveAnswer(q, options, response, raw)
+			// if something went wrong
+			if err != nil {
+				return err
+			}
+
+			answers[q.Name] = ans
+			raws[i] = raw
+			asked[i] = true
+			history = markAnswered(history, i)
+			i++
 		}
 
-		// tell the prompt to cleanup with the validated value
-		q.Prompt.Cleanup(&options.PromptConfig, ans)
+		// if some of the replayed answers didn't work out
+		if len(invalid) > 0 {
+			return invalid
+		}
 
+		// give the user a chance to look over their answers
+		if !options.Review || !interactive {
+			break
+		}
+		j, err := review(ctx, reader, qs, history, answers, options)
 		// if something went wrong
 		if err != nil {
-			// stop listening
+			return err
+		}
+		// if the user is happy with their answers
+		if j < 0 {
+			break
+		}
+
+		// ask the question again below the summary, starting from the answer they gave last time
+		q := qs[j]
+		if p, ok := q.Prompt.(wantsForget); ok {
+			p.forget()
+		}
+		if p, ok := q.Prompt.(wantsPrefill); ok {
+			p.prefill(raws[j])
+		}
+		raw, err := askQuestion(ctx, reader, q, options, response)
+		// if the user changed their mind, go back to the summary
+		if err == terminal.GoBackErr {
+			if p, ok := q.Prompt.(wantsClear); ok {
+				p.clear()
+			}
+			continue
+		}
+		// if there was a problem
+		if err != nil {
 			return err
 		}
 
-		// add it to the map
-		err = core.WriteAnswer(response, q.Name, ans)
+		ans, err := saveAnswer(q, options, response, raw)
 		// if something went wrong
 		if err != nil {
 			return err
 		}
 
+		answers[q.Name] = ans
+		raws[j] = raw
+		// ask the questions after it that apply now, and take back the ones that don't
+		i = j + 1
 	}
 
 	// return the response
 	return nil
 }
 
+// answeredAt returns where a question is in the ones that have been answered, or -1 if
+// it hasn't been.
+func answeredAt(history []int, i int) int {
+	if at := sort.SearchInts(history, i); at < len(history) && history[at] == i {
+		return at
+	}
+	return -1
+}
+
+// markAnswered adds a question to the ones that have been answered, keeping them in the
+// order they come in.
+func markAnswered(history []int, i int) []int {
+	at := sort.SearchInts(history, i)
+	history = append(history, 0)
+	copy(history[at+1:], history[at:])
+	history[at] = i
+	return history
+}
+
+// rememberAnswer holds on to what the response had for the question before its answer
+// is written, unless it already did, so the answer can be taken back out. If there is
+// no place for the answer, writing it reports the problem.
+func rememberAnswer(previous map[int]core.PreviousAnswer, response interface{}, qs []*Question, i int) {
+	if _, ok := previous[i]; ok {
+		return
+	}
+	if p, err := core.RememberAnswer(response, qs[i].Name); err == nil {
+		previous[i] = p
+	}
+}
+
+// forgetAnswers takes the answers to the given questions back out of the response,
+// starting from the last one so each name ends up the way it was before any of them.
+func forgetAnswers(previous map[int]core.PreviousAnswer, indices []int) {
+	for k := len(indices) - 1; k >= 0; k-- {
+		if p, ok := previous[indices[k]]; ok {
+			p.Restore()
+			delete(previous, indices[k])
+		}
+	}
+}
+
+// saveAnswer transforms the answer to a question, shows it to the user and writes it
+// to the response.
+func saveAnswer(q *Question, options *AskOptions, response interface{}, raw interface{}) (interface{}, error) {
+	ans := transform(q, raw)
+
+	// tell the prompt to cleanup with the validated value
+	err := q.Prompt.Cleanup(&options.PromptConfig, ans)
+	// if something went wrong
+	if err != nil {
+		return nil, err
+	}
+
+	// add it to the map
+	err = core.WriteAnswer(response, q.Name, ans)
+	// if something went wrong
+	if err != nil {
+		return nil, err
+	}
+
+	return ans, nil
+}
+
+// writeAnswer transforms the answer to a question and writes it to the response
+// without showing anything to the user.
+func writeAnswer(q *Question, response interface{}, raw interface{}) (interface{}, error) {
+	ans := transform(q, raw)
+
+	// add it to the map
+	err := core.WriteAnswer(response, q.Name, ans)
+	// if something went wrong
+	if err != nil {
+		return nil, err
+	}
+
+	return ans, nil
+}
+
+// askQuestion asks a single question, giving up when the context is done and falling
+// back to the prompt's default answer if the question times out.
+func askQuestion(ctx context.Context, reader *cancelableReader, q *Question, options *AskOptions, response interface{}) (interface{}, error) {
+	// questions that time out get their own deadline
+	qctx, cancel := questionContext(ctx, q, options)
+	defer cancel()
+	// an earlier answer only stands in for the default the one time the question is asked
+	if p, ok := q.Prompt.(wantsPrefill); ok {
+		defer p.prefill(nil)
+	}
+	if reader != nil {
+		reader.ctx = qctx
+	}
+
+	ans, err := ask(q, options, response)
+	if err == nil || qctx.Err() == nil {
+		return ans, err
+	}
+
+	// we were cancelled or ran out of time, so remove whatever the prompt left behind
+	if p, ok := q.Prompt.(wantsClear); ok {
+		p.clear()
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// the user didn't answer in time so fall back to the default
+	return askDefault(q, options, context.DeadlineExceeded)
+}
+
+// hasTimeout returns true if any of the questions can time out.
+func hasTimeout(qs []*Question, options *AskOptions) bool {
+	for _, q := range qs {
+		if questionTimeout(q, options) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLoader returns true if any of the questions loads its options in the background.
+func hasLoader(qs []*Question) bool {
+	for _, q := range qs {
+		if p, ok := q.Prompt.(loadsOptions); ok && p.loadsOptions() {
+			return true
+		}
+	}
+	return false
+}
+
+// questionContext returns the context to ask the question in, which has a deadline
+// if the question can time out.
+func questionContext(ctx context.Context, q *Question, options *AskOptions) (context.Context, context.CancelFunc) {
+	if timeout := questionTimeout(q, options); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// questionTimeout returns how long the user has to answer the question.
+func questionTimeout(q *Question, options *AskOptions) time.Duration {
+	if q.Timeout > 0 {
+		return q.Timeout
+	}
+	return options.Timeout
+}
+
+// askDefault answers a question with its prompt's default answer without waiting on
+// the user. Since there is nobody to ask for another answer, an invalid default is
+// returned as an error, and noDefault is returned if the prompt has no default.
+func askDefault(q *Question, options *AskOptions, noDefault error) (interface{}, error) {
+	defaulter, ok := q.Prompt.(Defaulter)
+	// if the prompt can't answer on its own there's nothing else we can do
+	if !ok {
+		return nil, noDefault
+	}
+
+	ans, err := defaulter.DefaultAnswer()
+	if err == ErrNoDefault {
+		return nil, noDefault
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ans, validate(q, options, ans)
+}
+
+// suppliedAnswer answers a question with the value given to WithAnswers, converted
+// to what the prompt would have returned.
+func suppliedAnswer(q *Question, options *AskOptions, value interface{}) (interface{}, error) {
+	ans := value
+	if converter, ok := q.Prompt.(AnswerConverter); ok {
+		var err error
+		ans, err = converter.ConvertAnswer(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ans, validate(q, options, ans)
+}
+
+// validate makes sure an answer that didn't come from the user passes every validator.
+func validate(q *Question, options *AskOptions, ans interface{}) error {
+	for _, validator := range questionValidators(q, options) {
+		if err := validator(ans); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isTerminal returns true if the input is a terminal the user can answer on.
+func isTerminal(in terminal.FileReader) bool {
+	return isatty.IsTerminal(in.Fd()) || isatty.IsCygwinTerminal(in.Fd())
+}
+
+// questionValidators returns every validator that applies to the question.
+func questionValidators(q *Question, options *AskOptions) []Validator {
+	// build up a list of validators that we have to apply to this question
+	validators := []Validator{}
+
+	// make sure to include the question specific one
+	if q.Validate != nil {
+		validators = append(validators, q.Validate)
+	}
+	// add any "global" validators
+	for _, validator := range options.Validators {
+		validators = append(validators, validator)
+	}
+
+	return validators
+}
+
+// transform applies the question's transformer to the answer, if it has one.
+func transform(q *Question, ans interface{}) interface{} {
+	if q.Transform != nil {
+		// check if we have a transformer available, if so
+		// then try to acquire the new representation of the
+		// answer, if the resulting answer is not nil.
+		if newAns := q.Transform(ans); newAns != nil {
+			return newAns
+		}
+	}
+	return ans
+}
+
+// ask prompts the user for a single question until they give a valid answer that
+// can be written to the response.
+func ask(q *Question, options *AskOptions, response interface{}) (interface{}, error) {
+	// grab the user input and save it
+	ans, err := q.Prompt.Prompt(&options.PromptConfig)
+	// if there was a problem
+	if err != nil {
+		return nil, err
+	}
+
+	// apply every validator to thte response
+	validators := questionValidators(q, options)
+	if response != nil {
+		validators = append(validators, convertible(q, response))
+	}
+	for _, validator := range validators {
+		// wait for a valid response
+		for invalid := validator(ans); invalid != nil; invalid = validator(ans) {
+			err := q.Prompt.Error(&options.PromptConfig, invalid)
+			// if there was a problem
+			if err != nil {
+				return nil, err
+			}
+
+			// ask for more input
+			if promptAgainer, ok := q.Prompt.(PromptAgainer); ok {
+				ans, err = promptAgainer.PromptAgain(&options.PromptConfig, ans, invalid)
+			} else {
+				ans, err = q.Prompt.Prompt(&options.PromptConfig)
+			}
+			// if there was a problem
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return ans, nil
+}
+
+// convertible returns a validator that makes sure the answer can be converted to the
+// type of the value it will be written to, so the user can be asked again instead of
+// finding out once they are done. The answer is written to a fresh copy of the response.
+func convertible(q *Question, response interface{}) Validator {
+	return func(ans interface{}) error {
+		target := reflect.TypeOf(response)
+		// custom types do their own conversion
+		if _, ok := response.(core.Settable); ok || target.Kind() != reflect.Ptr {
+			return nil
+		}
+
+		scratch := reflect.New(target.Elem())
+		// maps need to exist before they can be written to
+		if target.Elem().Kind() == reflect.Map {
+			scratch.Elem().Set(reflect.MakeMap(target.Elem()))
+		}
+
+		err := core.WriteAnswer(scratch.Interface(), q.Name, transform(q, ans))
+		if convErr, ok := err.(*core.ConversionError); ok {
+			return convErr
+		}
+		return nil
+	}
+}
+
 // paginate returns a single page of choices given the page size, the total list of
 // possible choices, and the current selected index in the total list.
 func paginate(pageSize int, choices []core.OptionAnswer, sel int) ([]core.OptionAnswer, int) {
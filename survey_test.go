@@ -1,8 +1,14 @@
 package survey
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -308,3 +314,752 @@ func TestAsk_returnsErrorIfTargetIsNil(t *testing.T) {
 		t.Error("Did not encounter error when asking with no where to record.")
 	}
 }
+
+func TestAskContext_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	answers := struct{ Name string }{}
+	done := make(chan struct{})
+	RunTest(t, func(c *expect.Console) {
+		c.ExpectString("What is your name?")
+		// give up on the question from the outside
+		cancel()
+		// the abandoned read is still waiting on the terminal (now back in line mode) so finish it off
+		<-done
+		c.SendLine("")
+		c.ExpectEOF()
+	}, func(stdio terminal.Stdio) error {
+		defer close(done)
+		err := AskContext(ctx, []*Question{
+			{
+				Name:   "name",
+				Prompt: &Input{Message: "What is your name?"},
+			},
+		}, &answers, WithStdio(stdio.In, stdio.Out, stdio.Err))
+		assert.Equal(t, context.Canceled, err)
+		return nil
+	})
+	assert.Equal(t, "", answers.Name)
+}
+
+func TestAskOneContext_deadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	answer := ""
+	done := make(chan struct{})
+	RunTest(t, func(c *expect.Console) {
+		c.ExpectString("Choose a color:")
+		// the abandoned read is still waiting on the terminal (now back in line mode) so finish it off
+		<-done
+		c.SendLine("")
+		c.ExpectEOF()
+	}, func(stdio terminal.Stdio) error {
+		defer close(done)
+		err := AskOneContext(ctx, &Select{
+			Message: "Choose a color:",
+			Options: []string{"red", "blue", "green"},
+		}, &answer, WithStdio(stdio.In, stdio.Out, stdio.Err))
+		assert.Equal(t, context.DeadlineExceeded, err)
+		return nil
+	})
+	assert.Equal(t, "", answer)
+}
+
+func TestAskContext_answersBeforeDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	answers := map[string]interface{}{}
+	RunTest(t, func(c *expect.Console) {
+		c.ExpectString("What is your name?")
+		c.SendLine("Larry Bird")
+		c.ExpectString("Is pizza your favorite food?")
+		c.SendLine("y")
+		c.ExpectEOF()
+	}, func(stdio terminal.Stdio) error {
+		return AskContext(ctx, []*Question{
+			{
+				Name:   "name",
+				Prompt: &Input{Message: "What is your name?"},
+			},
+			{
+				Name:   "pizza",
+				Prompt: &Confirm{Message: "Is pizza your favorite food?"},
+			},
+		}, &answers, WithStdio(stdio.In, stdio.Out, stdio.Err))
+	})
+	assert.Equal(t, map[string]interface{}{"name": "Larry Bird", "pizza": true}, answers)
+}
+
+func TestAsk_timeoutUsesDefault(t *testing.T) {
+	answers := map[string]interface{}{}
+	RunTest(t, func(c *expect.Console) {
+		c.ExpectString("What is your name?")
+		// let the first question time out and answer the second one
+		c.ExpectString("Is pizza your favorite food?")
+		c.SendLine("y")
+		c.ExpectEOF()
+	}, func(stdio terminal.Stdio) error {
+		return Ask([]*Question{
+			{
+				Name:    "name",
+				Prompt:  &Input{Message: "What is your name?", Default: "Johnny Appleseed"},
+				Timeout: 100 * time.Millisecond,
+			},
+			{
+				Name:   "pizza",
+				Prompt: &Confirm{Message: "Is pizza your favorite food?"},
+			},
+		}, &answers, WithStdio(stdio.In, stdio.Out, stdio.Err))
+	})
+	assert.Equal(t, map[string]interface{}{"name": "Johnny Appleseed", "pizza": true}, answers)
+}
+
+func TestAskOne_timeoutValidatesDefault(t *testing.T) {
+	answer := ""
+	done := make(chan struct{})
+	RunTest(t, func(c *expect.Console) {
+		c.ExpectString("What is your name?")
+		// the abandoned read is still waiting on the terminal (now back in line mode) so finish it off
+		<-done
+		c.SendLine("")
+		c.ExpectEOF()
+	}, func(stdio terminal.Stdio) error {
+		defer close(done)
+		err := AskOne(
+			&Input{Message: "What is your name?", Default: "Jo"},
+			&answer,
+			WithStdio(stdio.In, stdio.Out, stdio.Err),
+			WithTimeout(100*time.Millisecond),
+			WithValidator(MinLength(3)),
+		)
+		assert.EqualError(t, err, "value is too short. Min length is 3")
+		return nil
+	})
+}
+
+func TestAskOne_timeoutWithoutDefault(t *testing.T) {
+	answer := ""
+	done := make(chan struct{})
+	RunTest(t, func(c *expect.Console) {
+		c.ExpectString("Please type your password")
+		// the abandoned read is still waiting on the terminal (now back in line mode) so finish it off
+		<-done
+		c.SendLine("")
+		c.ExpectEOF()
+	}, func(stdio terminal.Stdio) error {
+		defer close(done)
+		err := AskOne(
+			&Password{Message: "Please type your password"},
+			&answer,
+			WithStdio(stdio.In, stdio.Out, stdio.Err),
+			WithTimeout(100*time.Millisecond),
+		)
+		assert.Equal(t, context.DeadlineExceeded, err)
+		return nil
+	})
+}
+
+func TestAsk_when(t *testing.T) {
+	questions := func() []*Question {
+		return []*Question{
+			{
+				Name:   "db",
+				Prompt: &Confirm{Message: "Do you need a database?"},
+			},
+			{
+				Name:   "host",
+				Prompt: &Input{Message: "Database host:"},
+				When: func(answers map[string]interface{}) bool {
+					return answers["db"] == true
+				},
+			},
+			{
+				Name:   "name",
+				Prompt: &Input{Message: "Project name:"},
+			},
+		}
+	}
+
+	type config struct {
+		DB   bool
+		Host string
+		Name string
+	}
+
+	t.Run("asks when the predicate passes", func(t *testing.T) {
+		answers := config{Host: "untouched"}
+		RunTest(t, func(c *expect.Console) {
+			c.ExpectString("Do you need a database?")
+			c.SendLine("y")
+			c.ExpectString("Database host:")
+			c.SendLine("localhost")
+			c.ExpectString("Project name:")
+			c.SendLine("survey")
+			c.ExpectEOF()
+		}, func(stdio terminal.Stdio) error {
+			return Ask(questions(), &answers, WithStdio(stdio.In, stdio.Out, stdio.Err))
+		})
+		assert.Equal(t, config{DB: true, Host: "localhost", Name: "survey"}, answers)
+	})
+
+	t.Run("skips when the predicate fails", func(t *testing.T) {
+		answers := config{Host: "untouched"}
+		RunTest(t, func(c *expect.Console) {
+			c.ExpectString("Do you need a database?")
+			c.SendLine("n")
+			c.ExpectString("Project name:")
+			c.SendLine("survey")
+			c.ExpectEOF()
+		}, func(stdio terminal.Stdio) error {
+			return Ask(questions(), &answers, WithStdio(stdio.In, stdio.Out, stdio.Err))
+		})
+		assert.Equal(t, config{DB: false, Host: "untouched", Name: "survey"}, answers)
+	})
+}
+
+func TestAsk_goBack(t *testing.T) {
+	answers := struct {
+		Name  string
+		Days  []string
+		Pizza bool
+	}{}
+	name := &Input{Message: "What is your name?"}
+	days := &MultiSelect{
+		Message: "Days:",
+		Options: []string{"Sunday", "Monday", "Tuesday"},
+	}
+	RunTest(t, func(c *expect.Console) {
+		c.ExpectString("What is your name?")
+		c.SendLine("Larry Bird")
+		c.ExpectString("Days:")
+		// check Monday
+		c.Send(string(terminal.KeyArrowDown))
+		c.SendLine(" ")
+		c.ExpectString("Is pizza your favorite food?")
+		// go back twice
+		c.Send("\x1b[Z")
+		c.ExpectString("Days:")
+		c.Send("\x1b[Z")
+		// the earlier answer is now the default
+		c.ExpectString("What is your name? (Larry Bird)")
+		c.SendLine("")
+		// Monday is still checked so just accept it
+		c.ExpectString("Days:")
+		c.SendLine("")
+		c.ExpectString("Is pizza your favorite food?")
+		c.SendLine("y")
+		c.ExpectEOF()
+	}, func(stdio terminal.Stdio) error {
+		return Ask([]*Question{
+			{
+				Name:   "name",
+				Prompt: name,
+			},
+			{
+				Name:   "days",
+				Prompt: days,
+			},
+			{
+				Name:   "pizza",
+				Prompt: &Confirm{Message: "Is pizza your favorite food?"},
+			},
+		}, &answers, WithStdio(stdio.In, stdio.Out, stdio.Err))
+	})
+	assert.Equal(t, "Larry Bird", answers.Name)
+	assert.Equal(t, []string{"Monday"}, answers.Days)
+	assert.True(t, answers.Pizza)
+	// the earlier answers were never made the defaults of the prompts
+	assert.Equal(t, "", name.Default)
+	assert.Nil(t, days.Default)
+}
+
+// petAnswers links back to itself and holds on to a lock, which going back has to
+// leave alone.
+type petAnswers struct {
+	Pet    bool
+	Kind   string
+	Source string
+	Self   *petAnswers
+	Lock   *sync.Mutex
+}
+
+func TestAsk_goBackPastSkippedQuestion(t *testing.T) {
+	lock := &sync.Mutex{}
+	answers := &petAnswers{Kind: "unknown", Lock: lock}
+	answers.Self = answers
+	RunTest(t, func(c *expect.Console) {
+		c.ExpectString("Do you have a pet?")
+		c.SendLine("y")
+		c.ExpectString("What kind of pet?")
+		c.SendLine(string(terminal.KeyArrowDown))
+		c.ExpectString("How did you hear about us?")
+		// go back to the first question and change the answer
+		c.Send("\x1b[Z")
+		c.ExpectString("What kind of pet?")
+		c.Send("\x1b[Z")
+		c.ExpectString("Do you have a pet? (Y/n)")
+		c.SendLine("n")
+		// the kind of pet isn't asked for anymore
+		c.ExpectString("How did you hear about us?")
+		c.SendLine("")
+		c.ExpectEOF()
+	}, func(stdio terminal.Stdio) error {
+		return Ask([]*Question{
+			{
+				Name:   "pet",
+				Prompt: &Confirm{Message: "Do you have a pet?"},
+			},
+			{
+				Name:   "kind",
+				Prompt: &Select{Message: "What kind of pet?", Options: []string{"cat", "dog"}},
+				When:   func(answers map[string]interface{}) bool { return answers["pet"] == true },
+			},
+			{
+				Name:   "source",
+				Prompt: &Select{Message: "How did you hear about us?", Options: []string{"friends", "ads"}},
+			},
+		}, answers, WithStdio(stdio.In, stdio.Out, stdio.Err))
+	})
+	assert.False(t, answers.Pet)
+	// the answer to the skipped question was taken back out
+	assert.Equal(t, "unknown", answers.Kind)
+	assert.Equal(t, "friends", answers.Source)
+	// and the fields no question wrote to are the same as before
+	assert.True(t, answers.Self == answers)
+	assert.True(t, answers.Lock == lock)
+}
+
+func TestAskOne_goBackAsksAgain(t *testing.T) {
+	answer := ""
+	RunTest(t, func(c *expect.Console) {
+		c.ExpectString("What is your name?")
+		// there is nothing to go back to
+		c.Send("\x1b[Z")
+		c.ExpectString("What is your name?")
+		c.SendLine("Larry Bird")
+		c.ExpectEOF()
+	}, func(stdio terminal.Stdio) error {
+		return AskOne(&Input{Message: "What is your name?"}, &answer, WithStdio(stdio.In, stdio.Out, stdio.Err))
+	})
+	assert.Equal(t, "Larry Bird", answer)
+}
+
+func TestAsk_review(t *testing.T) {
+	answers := map[string]interface{}{}
+	RunTest(t, func(c *expect.Console) {
+		c.ExpectString("What is your name?")
+		c.SendLine("Larry Bird")
+		c.ExpectString("Please type your password")
+		c.SendLine("hunter2")
+		c.ExpectString("Choose a color:")
+		c.SendLine(string(terminal.KeyArrowDown))
+		c.ExpectString("Review your answers:")
+		c.ExpectString("name   Larry Bird")
+		// the password isn't given away
+		c.ExpectString("token  ********")
+		c.ExpectString("color  blue")
+		// answer the name again
+		c.SendLine(string(terminal.KeyArrowDown))
+		c.ExpectString("What is your name? (Larry Bird)")
+		c.SendLine("Johnny Appleseed")
+		c.ExpectString("name   Johnny Appleseed")
+		// accept the answers
+		c.SendLine("")
+		c.ExpectEOF()
+	}, func(stdio terminal.Stdio) error {
+		return Ask([]*Question{
+			{
+				Name:   "name",
+				Prompt: &Input{Message: "What is your name?"},
+			},
+			{
+				Name:   "token",
+				Prompt: &Password{Message: "Please type your password"},
+			},
+			{
+				Name: "color",
+				Prompt: &Select{
+					Message: "Choose a color:",
+					Options: []string{"red", "blue", "green"},
+				},
+			},
+		}, &answers, WithStdio(stdio.In, stdio.Out, stdio.Err), WithReview())
+	})
+	assert.Equal(t, "Johnny Appleseed", answers["name"])
+	assert.Equal(t, "hunter2", answers["token"])
+	assert.Equal(t, core.OptionAnswer{Index: 1, Value: "blue"}, answers["color"])
+}
+
+func TestAsk_reviewChangesWhichQuestionsApply(t *testing.T) {
+	answers := map[string]interface{}{}
+	RunTest(t, func(c *expect.Console) {
+		c.ExpectString("Do you have a pet?")
+		c.SendLine("n")
+		c.ExpectString("pet  No")
+		// saying yes asks the question that didn't apply before
+		c.SendLine(string(terminal.KeyArrowDown))
+		c.ExpectString("Do you have a pet?")
+		c.SendLine("y")
+		c.ExpectString("What kind of pet?")
+		c.SendLine(string(terminal.KeyArrowDown))
+		c.ExpectString("kind  dog")
+		// and saying no again takes its answer back out
+		c.SendLine(string(terminal.KeyArrowDown))
+		c.ExpectString("Do you have a pet?")
+		c.SendLine("n")
+		c.ExpectString("Review your answers:")
+		c.SendLine("")
+		c.ExpectEOF()
+	}, func(stdio terminal.Stdio) error {
+		return Ask([]*Question{
+			{
+				Name:   "pet",
+				Prompt: &Confirm{Message: "Do you have a pet?"},
+			},
+			{
+				Name:   "kind",
+				Prompt: &Select{Message: "What kind of pet?", Options: []string{"cat", "dog"}},
+				When:   func(answers map[string]interface{}) bool { return answers["pet"] == true },
+			},
+		}, &answers, WithStdio(stdio.In, stdio.Out, stdio.Err), WithReview())
+	})
+	assert.Equal(t, map[string]interface{}{"pet": false}, answers)
+}
+
+func TestReviewAnswer(t *testing.T) {
+	regions := []Choice{
+		{Label: "US East", Value: "us-east-1"},
+		{Label: "EU West", Value: "eu-west-1"},
+	}
+	tests := []struct {
+		prompt   Prompt
+		answer   interface{}
+		expected string
+	}{
+		{&Input{}, "Larry Bird", "Larry Bird"},
+		{&Confirm{}, true, "Yes"},
+		{&Password{}, "hunter2", "********"},
+		{&Select{Choices: regions}, core.OptionAnswer{Index: 1, Value: "eu-west-1"}, "EU West"},
+		{
+			&MultiSelect{Choices: regions},
+			[]core.OptionAnswer{{Index: 0, Value: "us-east-1"}, {Index: 1, Value: "eu-west-1"}},
+			"US East, EU West",
+		},
+		{&TreeSelect{}, []string{"Europe", "Germany", "Berlin"}, "Europe / Germany / Berlin"},
+		{&KeyValue{}, map[string]string{"tier": "web", "env": "prod"}, "env=prod, tier=web"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, reviewAnswer(test.prompt, test.answer))
+	}
+}
+
+// pipeStdio returns stdio that isn't a terminal, like when the input is redirected.
+func pipeStdio(t *testing.T) (terminal.FileReader, terminal.FileWriter, func()) {
+	r, w, err := os.Pipe()
+	require.Nil(t, err)
+	return r, w, func() {
+		r.Close()
+		w.Close()
+	}
+}
+
+func TestAsk_withAnswers(t *testing.T) {
+	in, out, closeStdio := pipeStdio(t)
+	defer closeStdio()
+
+	answers := struct {
+		Name   string
+		Color  string
+		Agree  bool
+		Colors []string
+	}{}
+	err := Ask(
+		[]*Question{
+			{
+				Name:      "name",
+				Prompt:    &Input{Message: "What is your name?"},
+				Validate:  Required,
+				Transform: Title,
+			},
+			{
+				Name:   "color",
+				Prompt: &Select{Message: "Choose a color:", Options: []string{"red", "blue", "green"}},
+			},
+			{
+				Name:   "agree",
+				Prompt: &Confirm{Message: "Do you agree?"},
+			},
+			{
+				Name:   "colors",
+				Prompt: &MultiSelect{Message: "Choose colors:", Options: []string{"red", "blue", "green"}},
+			},
+		},
+		&answers,
+		WithStdio(in, out, out),
+		WithAnswers(map[string]interface{}{
+			"name":   "larry bird",
+			"color":  "blue",
+			"agree":  "yes",
+			"colors": []string{"green", "red"},
+		}),
+	)
+	require.Nil(t, err)
+
+	assert.Equal(t, "Larry Bird", answers.Name)
+	assert.Equal(t, "blue", answers.Color)
+	assert.True(t, answers.Agree)
+	assert.Equal(t, []string{"green", "red"}, answers.Colors)
+}
+
+func TestAsk_withAnswersValidates(t *testing.T) {
+	in, out, closeStdio := pipeStdio(t)
+	defer closeStdio()
+
+	answer := ""
+	err := Ask(
+		[]*Question{
+			{
+				Name:     "name",
+				Prompt:   &Input{Message: "What is your name?"},
+				Validate: MinLength(5),
+			},
+		},
+		&answer,
+		WithStdio(in, out, out),
+		WithAnswers(map[string]interface{}{"name": "Bob"}),
+	)
+	assert.NotNil(t, err)
+}
+
+func TestAsk_withAnswersConverts(t *testing.T) {
+	in, out, closeStdio := pipeStdio(t)
+	defer closeStdio()
+
+	answer := ""
+	err := AskOne(
+		&Select{Message: "Choose a color:", Options: []string{"red", "blue", "green"}},
+		&answer,
+		WithStdio(in, out, out),
+		WithAnswers(map[string]interface{}{"": "purple"}),
+	)
+	assert.EqualError(t, err, `"purple" is not one of the options`)
+}
+
+func TestAsk_noTTYUsesDefaults(t *testing.T) {
+	in, out, closeStdio := pipeStdio(t)
+	defer closeStdio()
+
+	answers := map[string]interface{}{}
+	err := Ask(
+		[]*Question{
+			{
+				Name:   "name",
+				Prompt: &Input{Message: "What is your name?", Default: "Johnny Appleseed"},
+			},
+			{
+				Name:   "color",
+				Prompt: &Select{Message: "Choose a color:", Options: []string{"red", "blue", "green"}, Default: "green"},
+			},
+		},
+		&answers,
+		WithStdio(in, out, out),
+		WithReview(),
+	)
+	require.Nil(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"name":  "Johnny Appleseed",
+		"color": core.OptionAnswer{Value: "green", Index: 2},
+	}, answers)
+}
+
+func TestAskOne_noTTYWithoutDefault(t *testing.T) {
+	in, out, closeStdio := pipeStdio(t)
+	defer closeStdio()
+
+	answer := ""
+	err := AskOne(
+		&Password{Message: "Please type your password"},
+		&answer,
+		WithStdio(in, out, out),
+	)
+	assert.Equal(t, ErrNoTTY, err)
+}
+
+func TestAskOne_noTTYInputWithoutDefault(t *testing.T) {
+	in, out, closeStdio := pipeStdio(t)
+	defer closeStdio()
+
+	answer := "unchanged"
+	err := AskOne(
+		&Input{Message: "What is your name?"},
+		&answer,
+		WithStdio(in, out, out),
+	)
+	assert.Equal(t, ErrNoTTY, err)
+	assert.Equal(t, "unchanged", answer)
+}
+
+func TestAskOne_noTTYMultiSelectLimits(t *testing.T) {
+	in, out, closeStdio := pipeStdio(t)
+	defer closeStdio()
+
+	// an empty answer isn't enough when options have to be picked
+	answer := []string{}
+	err := AskOne(
+		&MultiSelect{Message: "Days:", Options: []string{"Monday", "Tuesday"}, MinSelected: 1},
+		&answer,
+		WithStdio(in, out, out),
+	)
+	assert.Equal(t, ErrNoTTY, err)
+
+	// and a default with too many options isn't accepted
+	err = AskOne(
+		&MultiSelect{Message: "Days:", Options: []string{"Monday", "Tuesday"}, MaxSelected: 1, Default: []int{0, 1}},
+		&answer,
+		WithStdio(in, out, out),
+	)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "select at most 1 option", err.Error())
+	}
+	assert.Empty(t, answer)
+}
+
+func TestAsk_recordAndReplay(t *testing.T) {
+	in, out, closeStdio := pipeStdio(t)
+	defer closeStdio()
+	dir, err := ioutil.TempDir("", "survey")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "answers.json")
+
+	questions := []*Question{
+		{
+			Name:   "name",
+			Prompt: &Input{Message: "What is your name?"},
+		},
+		{
+			Name:   "color",
+			Prompt: &Select{Message: "Choose a color:", Options: []string{"red", "blue", "green"}},
+		},
+		{
+			Name:   "colors",
+			Prompt: &MultiSelect{Message: "Choose colors:", Options: []string{"red", "blue", "green"}},
+		},
+		{
+			Name:   "agree",
+			Prompt: &Confirm{Message: "Do you agree?"},
+		},
+		{
+			Name:   "token",
+			Prompt: &Password{Message: "Please type your token"},
+		},
+	}
+
+	recorded := map[string]interface{}{}
+	err = Ask(
+		questions,
+		&recorded,
+		WithStdio(in, out, out),
+		WithRecord(path),
+		WithAnswers(map[string]interface{}{
+			"name":   "Larry Bird",
+			"color":  "blue",
+			"colors": []string{"red", "green"},
+			"agree":  true,
+			"token":  "hunter2",
+		}),
+	)
+	require.Nil(t, err)
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		require.Nil(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	// the password was left out of the recording
+	contents, err := ioutil.ReadFile(path)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"name": "Larry Bird",
+		"color": {"Value": "blue", "Index": 1},
+		"colors": [{"Value": "red", "Index": 0}, {"Value": "green", "Index": 2}],
+		"agree": true
+	}`, string(contents))
+
+	// so it has to be supplied again when replaying
+	replayed := map[string]interface{}{}
+	err = Ask(
+		questions,
+		&replayed,
+		WithStdio(in, out, out),
+		WithReplay(path),
+		WithAnswers(map[string]interface{}{"token": "hunter2"}),
+	)
+	require.Nil(t, err)
+	assert.Equal(t, recorded, replayed)
+}
+
+func TestAsk_replayReportsInvalidAnswers(t *testing.T) {
+	in, out, closeStdio := pipeStdio(t)
+	defer closeStdio()
+	dir, err := ioutil.TempDir("", "survey")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "answers.json")
+
+	err = ioutil.WriteFile(path, []byte(`{
+		"name": "Bob",
+		"color": {"Value": "purple", "Index": 3},
+		"agree": true
+	}`), 0644)
+	require.Nil(t, err)
+
+	answers := map[string]interface{}{}
+	err = Ask(
+		[]*Question{
+			{
+				Name:     "name",
+				Prompt:   &Input{Message: "What is your name?"},
+				Validate: MinLength(5),
+			},
+			{
+				Name:   "color",
+				Prompt: &Select{Message: "Choose a color:", Options: []string{"red", "blue", "green"}},
+			},
+			{
+				Name:   "agree",
+				Prompt: &Confirm{Message: "Do you agree?"},
+			},
+		},
+		&answers,
+		WithStdio(in, out, out),
+		WithReplay(path),
+	)
+	require.IsType(t, ReplayError{}, err)
+
+	invalid := err.(ReplayError)
+	assert.Len(t, invalid, 2)
+	assert.Contains(t, invalid, "name")
+	assert.Contains(t, invalid, "color")
+	assert.Equal(t, map[string]interface{}{"agree": true}, answers)
+}
+
+func TestAskOne_repromptsWhenAnswerDoesNotConvert(t *testing.T) {
+	answer := 0
+	RunTest(t, func(c *expect.Console) {
+		c.ExpectString("How old are you?")
+		c.SendLine("old")
+		c.ExpectString("could not convert answer to int")
+		c.SendLine("32")
+		c.ExpectEOF()
+	}, func(stdio terminal.Stdio) error {
+		return AskOne(&Input{Message: "How old are you?"}, &answer, WithStdio(stdio.In, stdio.Out, stdio.Err))
+	})
+	assert.Equal(t, 32, answer)
+}
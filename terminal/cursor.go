@@ -176,6 +176,9 @@ func (c *Cursor) Size(buf *bytes.Buffer) (*Coord, error) {
 	// ask for the current location
 	bottom, err := c.Location(buf)
 	if err != nil {
+		// put the cursor back the way we found it before bailing
+		c.Restore()
+		c.Show()
 		return nil, err
 	}
 
@@ -6,4 +6,6 @@ import (
 
 var (
 	InterruptErr = errors.New("interrupt")
+	// GoBackErr is returned when the user asks to return to the previous question
+	GoBackErr = errors.New("go back")
 )
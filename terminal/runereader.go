@@ -29,7 +29,29 @@ func (rr *RuneReader) printChar(char rune, mask rune) {
 	}
 }
 
-func (rr *RuneReader) ReadLine(mask rune) ([]rune, error) {
+// OnRuneFn is given each key the user presses while reading a line, before the line is
+// changed. If it returns true, ReadLine stops reading and returns the line and error it
+// returned instead.
+type OnRuneFn func(key rune, line []rune) ([]rune, bool, error)
+
+// FilterRuneFn decides what a regular key typed at the given index of the line puts
+// there. It returns the runes to insert, which can be more than the key itself, or
+// nothing to ignore the key.
+type FilterRuneFn func(key rune, line []rune, index int) []rune
+
+func (rr *RuneReader) ReadLine(mask rune, onRunes ...OnRuneFn) ([]rune, error) {
+	return rr.ReadLineWithDefault(mask, []rune{}, onRunes...)
+}
+
+// ReadLineWithDefault reads a line like ReadLine, starting from the given line as if
+// the user had already typed it.
+func (rr *RuneReader) ReadLineWithDefault(mask rune, d []rune, onRunes ...OnRuneFn) ([]rune, error) {
+	return rr.ReadLineFiltered(mask, d, nil, onRunes...)
+}
+
+// ReadLineFiltered reads a line like ReadLineWithDefault, letting the filter decide what
+// each regular key the user types puts in the line.
+func (rr *RuneReader) ReadLineFiltered(mask rune, d []rune, filter FilterRuneFn, onRunes ...OnRuneFn) ([]rune, error) {
 	line := []rune{}
 	// we only care about horizontal displacements from the origin so start counting at 0
 	index := 0
@@ -40,9 +62,23 @@ func (rr *RuneReader) ReadLine(mask rune) ([]rune, error) {
 	}
 
 	// we get the terminal width and height (if resized after this point the property might become invalid)
-	terminalSize, _ := cursor.Size(rr.Buffer())
+	terminalSize, err := cursor.Size(rr.Buffer())
+	if err != nil {
+		return line, err
+	}
+
+	// print the line we were given as if the user typed it
+	for _, r := range d {
+		line = append(line, r)
+		rr.printChar(r, mask)
+		index++
+	}
+
 	// we set the current location of the cursor once
-	cursorCurrent, _ := cursor.Location(rr.Buffer())
+	cursorCurrent, err := cursor.Location(rr.Buffer())
+	if err != nil {
+		return line, err
+	}
 
 	for {
 		// wait for some input
@@ -50,6 +86,12 @@ func (rr *RuneReader) ReadLine(mask rune) ([]rune, error) {
 		if err != nil {
 			return line, err
 		}
+		// let the caller handle the key first
+		for _, onRune := range onRunes {
+			if result, stop, err := onRune(r, line); stop {
+				return result, err
+			}
+		}
 		// increment cursor location
 		cursorCurrent.X++
 
@@ -84,6 +126,10 @@ func (rr *RuneReader) ReadLine(mask rune) ([]rune, error) {
 			// we're done processing the input, and treat interrupt like an error
 			return line, InterruptErr
 		}
+		// if the user wants to go back to the previous question
+		if r == SpecialKeyShiftTab {
+			return line, GoBackErr
+		}
 
 		// allow for backspace/delete editing of inputs
 		if r == KeyBackspace || r == KeyDelete {
@@ -263,54 +309,59 @@ func (rr *RuneReader) ReadLine(mask rune) ([]rune, error) {
 			continue
 		}
 
-		// the user pressed a regular key
-
-		// if we are at the end of the line
-		if index == len(line) {
-			// just append the character at the end of the line
-			line = append(line, r)
-			// save the location of the cursor
-			index++
-			// print out the character
-			rr.printChar(r, mask)
-		} else {
-			// we are in the middle of the word so we need to insert the character the user pressed
-			line = append(line[:index], append([]rune{r}, line[index:]...)...)
-			// save the current position of the cursor, as we have to move the cursor back to erase the current symbol
-			// and then move for each symbol in line[index:] to print it out, afterwards we want to restore
-			// cursor's location to its previous one.
-			cursor.Save()
-			EraseLine(rr.stdio.Out, ERASE_LINE_END)
-			// remove the symbol after the cursor
-			// print the updated line
-			for _, char := range line[index:] {
-				EraseLine(rr.stdio.Out, ERASE_LINE_END)
+		// the user pressed a regular key, which the filter can change
+		runes := []rune{r}
+		if filter != nil {
+			runes = filter(r, line, index)
+		}
+		for _, r := range runes {
+			// if we are at the end of the line
+			if index == len(line) {
+				// just append the character at the end of the line
+				line = append(line, r)
+				// save the location of the cursor
+				index++
 				// print out the character
-				rr.printChar(char, mask)
-				cursorCurrent.X++
-			}
-			// if we are at the last line, we want to visually insert a new line and append to it.
-			if cursorCurrent.CursorIsAtLineEnd(terminalSize) && cursorCurrent.Y == terminalSize.Y {
-				// add a new line to the terminal
-				fmt.Fprintln(rr.stdio.Out)
-				// restore the position of the cursor horizontally
-				cursor.Restore()
-				// restore the position of the cursor vertically
-				cursor.Up(1)
+				rr.printChar(r, mask)
 			} else {
-				// restore cursor
-				cursor.Restore()
-			}
-			// check if cursor needs to move to next line
-			cursorCurrent, _ = cursor.Location(rr.Buffer())
-			if cursorCurrent.CursorIsAtLineEnd(terminalSize) {
-				cursor.NextLine(1)
-			} else {
-				cursor.Forward(1)
-			}
-			// increment the index
-			index++
+				// we are in the middle of the word so we need to insert the character the user pressed
+				line = append(line[:index], append([]rune{r}, line[index:]...)...)
+				// save the current position of the cursor, as we have to move the cursor back to erase the current symbol
+				// and then move for each symbol in line[index:] to print it out, afterwards we want to restore
+				// cursor's location to its previous one.
+				cursor.Save()
+				EraseLine(rr.stdio.Out, ERASE_LINE_END)
+				// remove the symbol after the cursor
+				// print the updated line
+				for _, char := range line[index:] {
+					EraseLine(rr.stdio.Out, ERASE_LINE_END)
+					// print out the character
+					rr.printChar(char, mask)
+					cursorCurrent.X++
+				}
+				// if we are at the last line, we want to visually insert a new line and append to it.
+				if cursorCurrent.CursorIsAtLineEnd(terminalSize) && cursorCurrent.Y == terminalSize.Y {
+					// add a new line to the terminal
+					fmt.Fprintln(rr.stdio.Out)
+					// restore the position of the cursor horizontally
+					cursor.Restore()
+					// restore the position of the cursor vertically
+					cursor.Up(1)
+				} else {
+					// restore cursor
+					cursor.Restore()
+				}
+				// check if cursor needs to move to next line
+				cursorCurrent, _ = cursor.Location(rr.Buffer())
+				if cursorCurrent.CursorIsAtLineEnd(terminalSize) {
+					cursor.NextLine(1)
+				} else {
+					cursor.Forward(1)
+				}
+				// increment the index
+				index++
 
+			}
 		}
 	}
 }
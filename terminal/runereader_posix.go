@@ -37,6 +37,11 @@ func (rr *RuneReader) Buffer() *bytes.Buffer {
 	return rr.state.buf
 }
 
+// Buffered returns the number of bytes the user has typed that haven't been read yet.
+func (rr *RuneReader) Buffered() int {
+	return rr.state.reader.Buffered()
+}
+
 // For reading runes we just want to disable echo.
 func (rr *RuneReader) SetTermMode() error {
 	if _, _, err := syscall.Syscall6(syscall.SYS_IOCTL, uintptr(rr.stdio.In.Fd()), ioctlReadTermios, uintptr(unsafe.Pointer(&rr.state.term)), 0, 0, 0); err != 0 {
@@ -96,16 +101,25 @@ func (rr *RuneReader) ReadRune() (rune, int, error) {
 			return SpecialKeyHome, 1, nil
 		case 'F': // End button
 			return SpecialKeyEnd, 1, nil
+		case 'Z': // Shift+Tab
+			return SpecialKeyShiftTab, 1, nil
 		case '3': // Delete Button
 			// discard the following '~' key from buffer
 			rr.state.reader.Discard(1)
 			return SpecialKeyDelete, 1, nil
+		case '5': // Page Up button
+			// discard the following '~' key from buffer
+			rr.state.reader.Discard(1)
+			return SpecialKeyPageUp, 1, nil
+		case '6': // Page Down button
+			// discard the following '~' key from buffer
+			rr.state.reader.Discard(1)
+			return SpecialKeyPageDown, 1, nil
 		default:
 			// discard the following '~' key from buffer
 			rr.state.reader.Discard(1)
 			return IgnoreKey, 1, nil
 		}
-		return r, size, fmt.Errorf("Unknown Escape Sequence: %q", []rune{'\033', '[', r})
 	}
 	return r, size, err
 }
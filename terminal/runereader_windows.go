@@ -18,7 +18,10 @@ const (
 
 	// key codes for arrow keys
 	// https://msdn.microsoft.com/en-us/library/windows/desktop/dd375731(v=vs.85).aspx
+	VK_TAB    = 0x09
 	VK_DELETE = 0x2E
+	VK_PRIOR  = 0x21
+	VK_NEXT   = 0x22
 	VK_END    = 0x23
 	VK_HOME   = 0x24
 	VK_LEFT   = 0x25
@@ -28,6 +31,7 @@ const (
 
 	RIGHT_CTRL_PRESSED = 0x0004
 	LEFT_CTRL_PRESSED  = 0x0008
+	SHIFT_PRESSED      = 0x0010
 
 	ENABLE_ECHO_INPUT      uint32 = 0x0004
 	ENABLE_LINE_INPUT      uint32 = 0x0002
@@ -61,6 +65,12 @@ func (rr *RuneReader) Buffer() *bytes.Buffer {
 	return nil
 }
 
+// Buffered returns the number of bytes the user has typed that haven't been read yet.
+// Console input is read one key at a time, so there never are any.
+func (rr *RuneReader) Buffered() int {
+	return 0
+}
+
 func (rr *RuneReader) SetTermMode() error {
 	r, _, err := getConsoleMode.Call(uintptr(rr.stdio.In.Fd()), uintptr(unsafe.Pointer(&rr.state.term)))
 	// windows return 0 on error
@@ -91,6 +101,8 @@ func (rr *RuneReader) ReadRune() (rune, int, error) {
 	ir := &inputRecord{}
 	bytesRead := 0
 	for {
+		// this goes through the handle of rr.stdio.In rather than its Read method, so a
+		// read waiting on a key can't be interrupted by a reader wrapping the input
 		rv, _, e := readConsoleInput.Call(rr.stdio.In.Fd(), uintptr(unsafe.Pointer(ir)), 1, uintptr(unsafe.Pointer(&bytesRead)))
 		// windows returns non-zero to indicate success
 		if rv == 0 && e != nil {
@@ -112,6 +124,9 @@ func (rr *RuneReader) ReadRune() (rune, int, error) {
 		if key.wdControlKeyState&(LEFT_CTRL_PRESSED|RIGHT_CTRL_PRESSED) != 0 && key.unicodeChar == 'C' {
 			return KeyInterrupt, bytesRead, nil
 		}
+		if key.wdControlKeyState&SHIFT_PRESSED != 0 && key.wVirtualKeyCode == VK_TAB {
+			return SpecialKeyShiftTab, bytesRead, nil
+		}
 		// not a normal character so look up the input sequence from the
 		// virtual key code mappings (VK_*)
 		if key.unicodeChar == 0 {
@@ -130,6 +145,10 @@ func (rr *RuneReader) ReadRune() (rune, int, error) {
 				return SpecialKeyHome, bytesRead, nil
 			case VK_END:
 				return SpecialKeyEnd, bytesRead, nil
+			case VK_PRIOR:
+				return SpecialKeyPageUp, bytesRead, nil
+			case VK_NEXT:
+				return SpecialKeyPageDown, bytesRead, nil
 			default:
 				// not a virtual key that we care about so just continue on to
 				// the next input key
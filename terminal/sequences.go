@@ -17,11 +17,15 @@ const (
 	KeyInterrupt       = '\x03'
 	KeyEndTransmission = '\x04'
 	KeyEscape          = '\x1b'
+	KeyTab             = '\t'
 	KeyDeleteWord      = '\x17' // Ctrl+W
 	KeyDeleteLine      = '\x18' // Ctrl+X
 	SpecialKeyHome     = '\x01'
 	SpecialKeyEnd      = '\x11'
 	SpecialKeyDelete   = '\x12'
+	SpecialKeyShiftTab = '\x13'
+	SpecialKeyPageUp   = '\x14'
+	SpecialKeyPageDown = '\x15'
 	IgnoreKey          = '\000'
 )
 
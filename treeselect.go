@@ -0,0 +1,398 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+// TreeOption is an option of a TreeSelect, which can hold options of its own.
+type TreeOption struct {
+	Value   string
+	Options []TreeOption
+}
+
+/*
+TreeSelect is a prompt that presents nested options for the user to pick from. The
+right arrow expands an option and the left arrow collapses it again, and typing filters
+the options while keeping the ones leading to a match visible. Response type is a
+[]string holding the path to the selected option.
+
+	host := []string{}
+	prompt := &survey.TreeSelect{
+		Message: "Choose a host:",
+		Options: []survey.TreeOption{
+			{Value: "us-east", Options: []survey.TreeOption{
+				{Value: "us-east-1a", Options: []survey.TreeOption{{Value: "web-1"}, {Value: "web-2"}}},
+			}},
+			{Value: "eu-west", Options: []survey.TreeOption{
+				{Value: "eu-west-1a", Options: []survey.TreeOption{{Value: "db-1"}}},
+			}},
+		},
+	}
+	survey.AskOne(prompt, &host)
+*/
+type TreeSelect struct {
+	Renderer
+	Message       string
+	Options       []TreeOption
+	Default       []string
+	Help          string
+	PageSize      int
+	Filter        func(filter string, value string, index int) bool
+	filter        string
+	selectedIndex int
+	expanded      map[string]bool
+	showingHelp   bool
+	// the answer given last time, when going back to the question
+	previous []string
+}
+
+// TreeRow is an option of a TreeSelect the way it is shown in the list.
+type TreeRow struct {
+	Value    string
+	Path     []string
+	Depth    int
+	Indent   string
+	Parent   bool
+	Expanded bool
+}
+
+// TreeSelectTemplateData is the data available to the templates when processing
+type TreeSelectTemplateData struct {
+	TreeSelect
+	FilterMessage string
+	PageEntries   []TreeRow
+	SelectedIndex int
+	Answer        string
+	ShowAnswer    bool
+	ShowHelp      bool
+	Config        *PromptConfig
+}
+
+var TreeSelectQuestionTemplate = `
+{{- if .ShowHelp }}{{- color .Config.Icons.Help.Format }}{{ .Config.Icons.Help.Text }} {{ .Help }}{{color "reset"}}{{"\n"}}{{end}}
+{{- color .Config.Icons.Question.Format }}{{ .Config.Icons.Question.Text }} {{color "reset"}}
+{{- color "default+hb"}}{{ .Message }}{{ .FilterMessage }}{{color "reset"}}
+{{- if .ShowAnswer}}{{color "cyan"}} {{.Answer}}{{color "reset"}}{{"\n"}}
+{{- else}}
+  {{- "  "}}{{- color "cyan"}}[Use arrows to move and expand, type to filter{{- if and .Help (not .ShowHelp)}}, {{ .Config.HelpInput }} for more help{{end}}]{{color "reset"}}
+  {{- "\n"}}
+  {{- range $ix, $row := .PageEntries}}
+    {{- if eq $ix $.SelectedIndex }}{{color $.Config.Icons.SelectFocus.Format }}{{ $.Config.Icons.SelectFocus.Text }} {{else}}{{color "default"}}  {{end}}
+    {{- $row.Indent}}
+    {{- if $row.Expanded}}{{color $.Config.Icons.ExpandedOption.Format }}{{ $.Config.Icons.ExpandedOption.Text }} {{color "reset"}}
+    {{- else if $row.Parent}}{{color $.Config.Icons.CollapsedOption.Format }}{{ $.Config.Icons.CollapsedOption.Text }} {{color "reset"}}
+    {{- else}}{{"  "}}{{end}}
+    {{- if eq $ix $.SelectedIndex }}{{color $.Config.Icons.SelectFocus.Format }}{{end}}
+    {{- $row.Value}}
+    {{- color "reset"}}{{"\n"}}
+  {{- end}}
+{{- end}}`
+
+func (t *TreeSelect) Prompt(config *PromptConfig) (interface{}, error) {
+	// if there are no options to render
+	if len(t.Options) == 0 {
+		// we failed
+		return nil, errors.New("please provide options to select from")
+	}
+
+	// start off with the default selected and the options leading to it expanded
+	t.filter = ""
+	t.showingHelp = false
+	t.expanded = map[string]bool{}
+	dflt := t.prefilled().Default
+	for i := 1; i < len(dflt); i++ {
+		t.expanded[treeKey(dflt[:i])] = true
+	}
+	t.selectedIndex = t.rowIndex(t.rows(config), dflt)
+
+	cursor := t.NewCursor()
+	cursor.Hide()       // hide the cursor
+	defer cursor.Show() // show the cursor when we're done
+
+	// ask the question
+	err := t.render(config)
+	if err != nil {
+		return nil, err
+	}
+
+	rr := t.NewRuneReader()
+	rr.SetTermMode()
+	defer rr.RestoreTermMode()
+
+	// start waiting for input
+	for {
+		r, _, err := rr.ReadRune()
+		if err != nil {
+			return nil, err
+		}
+
+		rows := t.rows(config)
+		switch {
+		case r == terminal.KeyInterrupt:
+			return nil, terminal.InterruptErr
+		case r == terminal.SpecialKeyShiftTab:
+			return nil, terminal.GoBackErr
+		case r == terminal.KeyEnter || r == '\n' || r == terminal.KeyEndTransmission:
+			// if the filter matched something we're done
+			if t.selectedIndex < len(rows) {
+				return rows[t.selectedIndex].Path, nil
+			}
+		case r == terminal.KeyArrowUp && len(rows) > 0:
+			t.selectedIndex = (t.selectedIndex - 1 + len(rows)) % len(rows)
+		case r == terminal.KeyArrowDown && len(rows) > 0:
+			t.selectedIndex = (t.selectedIndex + 1) % len(rows)
+		case r == terminal.KeyArrowRight && t.selectedIndex < len(rows):
+			row := rows[t.selectedIndex]
+			if row.Parent && !row.Expanded {
+				t.expanded[treeKey(row.Path)] = true
+			} else if row.Expanded {
+				// move into an option that is already open
+				t.selectedIndex++
+			}
+		case r == terminal.KeyArrowLeft && t.selectedIndex < len(rows):
+			row := rows[t.selectedIndex]
+			if row.Expanded && t.filter == "" {
+				delete(t.expanded, treeKey(row.Path))
+			} else if row.Depth > 0 {
+				// move out to the option this one is in
+				t.selectedIndex = t.rowIndex(rows, row.Path[:row.Depth])
+			}
+		case string(r) == config.HelpInput && t.Help != "":
+			t.showingHelp = true
+		case r == terminal.KeyDeleteWord || r == terminal.KeyDeleteLine:
+			t.setFilter("", rows, config)
+		case r == terminal.KeyDelete || r == terminal.KeyBackspace:
+			// remove the last character from the filter
+			if filter := []rune(t.filter); len(filter) > 0 {
+				t.setFilter(string(filter[:len(filter)-1]), rows, config)
+			}
+		case r >= terminal.KeySpace:
+			t.setFilter(t.filter+string(r), rows, config)
+		}
+
+		err = t.render(config)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *TreeSelect) render(config *PromptConfig) error {
+	// figure out the page size
+	pageSize := t.PageSize
+	// if we dont have a specific one
+	if pageSize == 0 {
+		// grab the global value
+		pageSize = config.PageSize
+	}
+
+	// paginate the rows the same way as the options of a select
+	rows := t.rows(config)
+	options := make([]core.OptionAnswer, len(rows))
+	for i, row := range rows {
+		options[i] = core.OptionAnswer{Value: row.Value, Index: i}
+	}
+	page, idx := paginate(pageSize, options, t.selectedIndex)
+	entries := []TreeRow{}
+	if len(page) > 0 {
+		entries = rows[page[0].Index : page[0].Index+len(page)]
+	}
+
+	data := TreeSelectTemplateData{
+		TreeSelect:    *t,
+		PageEntries:   entries,
+		SelectedIndex: idx,
+		ShowHelp:      t.showingHelp,
+		Config:        config,
+	}
+	if t.filter != "" {
+		data.FilterMessage = " " + t.filter
+	}
+
+	return t.Render(TreeSelectQuestionTemplate, data)
+}
+
+// setFilter changes the filter, keeping the selected option if it still matches.
+func (t *TreeSelect) setFilter(filter string, rows []TreeRow, config *PromptConfig) {
+	var selected []string
+	if t.selectedIndex < len(rows) {
+		selected = rows[t.selectedIndex].Path
+	}
+
+	t.filter = filter
+	t.selectedIndex = t.rowIndex(t.rows(config), selected)
+}
+
+// rows returns the options to show, in order.
+func (t *TreeSelect) rows(config *PromptConfig) []TreeRow {
+	// the filter to apply
+	match, _ := filterMatcher(t.Filter, nil, config)
+
+	index := 0
+	return t.appendRows([]TreeRow{}, t.Options, []string{}, &index, match)
+}
+
+// appendRows adds the rows for the options to the list. The options are numbered in the
+// order they come in the whole tree, counting depth first, which is the index the filter
+// is given. Every option is visited when filtering so the numbers don't depend on what
+// is expanded.
+func (t *TreeSelect) appendRows(rows []TreeRow, options []TreeOption, parent []string, index *int, match Matcher) []TreeRow {
+	for _, opt := range options {
+		optIndex := *index
+		*index++
+
+		path := append(append([]string{}, parent...), opt.Value)
+		row := TreeRow{
+			Value:  opt.Value,
+			Path:   path,
+			Depth:  len(parent),
+			Indent: strings.Repeat("  ", len(parent)),
+			Parent: len(opt.Options) > 0,
+		}
+
+		// if there is no filter applied only show the options that are open
+		if t.filter == "" {
+			row.Expanded = row.Parent && t.expanded[treeKey(path)]
+			rows = append(rows, row)
+			if row.Expanded {
+				rows = t.appendRows(rows, opt.Options, path, index, match)
+			}
+			continue
+		}
+
+		// otherwise show the options that match along with the ones leading to them
+		children := t.appendRows([]TreeRow{}, opt.Options, path, index, match)
+		if _, _, ok := match(t.filter, opt.Value, optIndex); len(children) > 0 || ok {
+			row.Expanded = len(children) > 0
+			rows = append(rows, row)
+			rows = append(rows, children...)
+		}
+	}
+	return rows
+}
+
+// rowIndex returns the index of the row with the given path, or 0 if it isn't shown.
+func (t *TreeSelect) rowIndex(rows []TreeRow, path []string) int {
+	for i, row := range rows {
+		if treeKey(row.Path) == treeKey(path) {
+			return i
+		}
+	}
+	return 0
+}
+
+// contains returns true if the path leads to one of the options.
+func (t *TreeSelect) contains(path []string) bool {
+	options := t.Options
+	for i, value := range path {
+		found := false
+		for _, opt := range options {
+			if opt.Value == value {
+				found = true
+				// look for the rest of the path in this option
+				if i < len(path)-1 {
+					options = opt.Options
+				}
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return len(path) > 0
+}
+
+// DefaultAnswer returns the answer the user would get by accepting the prompt
+// without moving the cursor.
+func (t *TreeSelect) DefaultAnswer() (interface{}, error) {
+	// if there are no options to choose from
+	if len(t.Options) == 0 {
+		// we failed
+		return nil, errors.New("please provide options to select from")
+	}
+
+	dflt := t.prefilled().Default
+	if len(dflt) == 0 {
+		return []string{t.Options[0].Value}, nil
+	}
+	return t.ConvertAnswer(dflt)
+}
+
+// ConvertAnswer turns a supplied answer into the path to an option. Besides a
+// []string, the answer can be a list of values or a single string with the
+// values separated by "/".
+func (t *TreeSelect) ConvertAnswer(value interface{}) (interface{}, error) {
+	path := []string{}
+	switch val := value.(type) {
+	case []string:
+		path = val
+	case []interface{}:
+		for _, item := range val {
+			text, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("cannot use a %T in the path to an option", item)
+			}
+			path = append(path, text)
+		}
+	case string:
+		path = strings.Split(val, "/")
+	default:
+		return nil, fmt.Errorf("cannot use a %T as the path to an option", value)
+	}
+
+	if !t.contains(path) {
+		return nil, fmt.Errorf("%q is not one of the options", strings.Join(path, "/"))
+	}
+	return path, nil
+}
+
+// prefill makes an earlier answer the default when going back to the question, or
+// goes back to Default when there is none.
+func (t *TreeSelect) prefill(ans interface{}) {
+	t.previous = nil
+	if val, ok := ans.([]string); ok {
+		t.previous = val
+	}
+}
+
+// prefilled is the prompt as the user sees it, with the earlier answer in place of
+// Default.
+func (t *TreeSelect) prefilled() TreeSelect {
+	prompt := *t
+	if t.previous != nil {
+		prompt.Default = t.previous
+	}
+	return prompt
+}
+
+// formatAnswer returns the answer the way it is shown once the question is answered.
+func (t *TreeSelect) formatAnswer(val interface{}) string {
+	// transformers can turn the answer into something other than a path
+	if path, ok := val.([]string); ok {
+		return strings.Join(path, " / ")
+	}
+	return fmt.Sprint(val)
+}
+
+func (t *TreeSelect) Cleanup(config *PromptConfig, val interface{}) error {
+	return t.Render(
+		TreeSelectQuestionTemplate,
+		TreeSelectTemplateData{
+			TreeSelect: *t,
+			Answer:     t.formatAnswer(val),
+			ShowAnswer: true,
+			Config:     config,
+		},
+	)
+}
+
+// treeKey identifies the option at the end of the path.
+func treeKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
@@ -0,0 +1,226 @@
+package survey
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	expect "github.com/Netflix/go-expect"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	// disable color output for all prompts to simplify testing
+	core.DisableColor = true
+}
+
+// inventory returns a small tree of hosts to pick from.
+func inventory() []TreeOption {
+	return []TreeOption{
+		{Value: "us-east", Options: []TreeOption{
+			{Value: "zone-a", Options: []TreeOption{{Value: "web-1"}, {Value: "web-2"}}},
+		}},
+		{Value: "eu-west", Options: []TreeOption{
+			{Value: "zone-b", Options: []TreeOption{{Value: "db-1"}}},
+		}},
+	}
+}
+
+func TestTreeSelectRender(t *testing.T) {
+	prompt := TreeSelect{
+		Message: "Pick a host:",
+		Options: inventory(),
+	}
+	prompt.expanded = map[string]bool{"us-east": true}
+
+	tests := []struct {
+		title    string
+		filter   string
+		data     TreeSelectTemplateData
+		expected string
+	}{
+		{
+			"Test TreeSelect question output",
+			"",
+			TreeSelectTemplateData{SelectedIndex: 1},
+			fmt.Sprintf(
+				"%s Pick a host:  [Use arrows to move and expand, type to filter]\n  - us-east\n%s   + zone-a\n  + eu-west\n",
+				defaultIcons().Question.Text, defaultIcons().SelectFocus.Text,
+			),
+		},
+		{
+			"Test TreeSelect question output with filter",
+			"db",
+			TreeSelectTemplateData{FilterMessage: " db"},
+			fmt.Sprintf(
+				"%s Pick a host: db  [Use arrows to move and expand, type to filter]\n%s - eu-west\n    - zone-b\n        db-1\n",
+				defaultIcons().Question.Text, defaultIcons().SelectFocus.Text,
+			),
+		},
+		{
+			"Test TreeSelect answer output",
+			"",
+			TreeSelectTemplateData{Answer: "us-east / zone-a / web-1", ShowAnswer: true},
+			fmt.Sprintf("%s Pick a host: us-east / zone-a / web-1\n", defaultIcons().Question.Text),
+		},
+	}
+
+	for _, test := range tests {
+		r, w, err := os.Pipe()
+		assert.Nil(t, err, test.title)
+
+		prompt.WithStdio(terminal.Stdio{Out: w})
+		prompt.filter = test.filter
+		test.data.TreeSelect = prompt
+		test.data.PageEntries = prompt.rows(defaultPromptConfig())
+
+		// set the runtime config
+		test.data.Config = defaultPromptConfig()
+
+		err = prompt.Render(
+			TreeSelectQuestionTemplate,
+			test.data,
+		)
+		assert.Nil(t, err, test.title)
+
+		w.Close()
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+
+		assert.Contains(t, buf.String(), test.expected, test.title)
+	}
+}
+
+func TestTreeSelectFilterIndex(t *testing.T) {
+	indexes := map[string]int{}
+	prompt := TreeSelect{
+		Options: inventory(),
+		Filter: func(filter string, value string, index int) bool {
+			indexes[value] = index
+			return false
+		},
+	}
+	prompt.filter = "x"
+
+	prompt.rows(defaultPromptConfig())
+	assert.Equal(t, map[string]int{
+		"us-east": 0,
+		"zone-a":  1,
+		"web-1":   2,
+		"web-2":   3,
+		"eu-west": 4,
+		"zone-b":  5,
+		"db-1":    6,
+	}, indexes)
+}
+
+func TestTreeSelectPrompt(t *testing.T) {
+	tests := []PromptTest{
+		{
+			"Test TreeSelect prompt interaction",
+			&TreeSelect{Message: "Pick a host:", Options: inventory()},
+			func(c *expect.Console) {
+				c.ExpectString("Pick a host:")
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			[]string{"us-east"},
+		},
+		{
+			"Test TreeSelect prompt expands options",
+			&TreeSelect{Message: "Pick a host:", Options: inventory()},
+			func(c *expect.Console) {
+				c.ExpectString("Pick a host:")
+				// open the region, move into it and open the zone
+				c.Send(string(terminal.KeyArrowRight))
+				c.Send(string(terminal.KeyArrowRight))
+				c.Send(string(terminal.KeyArrowRight))
+				// move past the first host
+				c.Send(string(terminal.KeyArrowDown))
+				c.Send(string(terminal.KeyArrowDown))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			[]string{"us-east", "zone-a", "web-2"},
+		},
+		{
+			"Test TreeSelect prompt collapses options",
+			&TreeSelect{Message: "Pick a host:", Options: inventory(), Default: []string{"us-east", "zone-a", "web-1"}},
+			func(c *expect.Console) {
+				c.ExpectString("Pick a host:")
+				// move out to the zone and close it, then go to the next region
+				c.Send(string(terminal.KeyArrowLeft))
+				c.Send(string(terminal.KeyArrowLeft))
+				c.Send(string(terminal.KeyArrowDown))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			[]string{"eu-west"},
+		},
+		{
+			"Test TreeSelect prompt filters options",
+			&TreeSelect{Message: "Pick a host:", Options: inventory()},
+			func(c *expect.Console) {
+				c.ExpectString("Pick a host:")
+				c.Send("db")
+				// the region and zone leading to the match come first
+				c.Send(string(terminal.KeyArrowUp))
+				c.SendLine("")
+				c.ExpectEOF()
+			},
+			[]string{"eu-west", "zone-b", "db-1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			RunPromptTest(t, test)
+		})
+	}
+}
+
+func TestTreeSelectConvertAnswer(t *testing.T) {
+	prompt := &TreeSelect{Options: inventory()}
+
+	for _, value := range []interface{}{
+		[]string{"us-east", "zone-a", "web-2"},
+		[]interface{}{"us-east", "zone-a", "web-2"},
+		"us-east/zone-a/web-2",
+	} {
+		answer, err := prompt.ConvertAnswer(value)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"us-east", "zone-a", "web-2"}, answer)
+	}
+
+	for _, value := range []interface{}{"us-east/zone-b", []string{}, 3} {
+		_, err := prompt.ConvertAnswer(value)
+		assert.NotNil(t, err, "converting %v", value)
+	}
+}
+
+func TestTreeSelectFormatAnswer(t *testing.T) {
+	prompt := &TreeSelect{Options: inventory()}
+
+	assert.Equal(t, "us-east / zone-a / web-2", prompt.formatAnswer([]string{"us-east", "zone-a", "web-2"}))
+	// answers changed by a transformer are shown as they are
+	assert.Equal(t, "web-2", prompt.formatAnswer("web-2"))
+}
+
+func TestAskOne_treeSelect(t *testing.T) {
+	in, out, closeStdio := pipeStdio(t)
+	defer closeStdio()
+
+	host := []string{}
+	err := AskOne(
+		&TreeSelect{Message: "Pick a host:", Options: inventory()},
+		&host,
+		WithStdio(in, out, out),
+		WithAnswers(map[string]interface{}{"": "eu-west/zone-b/db-1"}),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"eu-west", "zone-b", "db-1"}, host)
+}